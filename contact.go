@@ -0,0 +1,136 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Contact roles of whois info, the role names are the same as the whois contact keys
+const (
+	RoleRegistrar      = "registrar"
+	RoleRegistrant     = "registrant"
+	RoleAdministrative = "administrative"
+	RoleTechnical      = "technical"
+	RoleBilling        = "billing"
+)
+
+// ContactRoles is the contact roles of whois info in the order of display
+var ContactRoles = []string{RoleRegistrar, RoleRegistrant, RoleAdministrative, RoleTechnical, RoleBilling}
+
+// redactedMarkers is the placeholder phrases used in place of redacted contact data
+var redactedMarkers = []string{
+	"redacted",
+	"not disclosed",
+	"data protected",
+	"withheld",
+}
+
+// IsRedacted returns if contact data is withheld for privacy, either flagged by the registry,
+// e.g. the .fr "anonymous: YES", or replaced by a placeholder in the name, organization or email
+func (c *Contact) IsRedacted() bool {
+	if c == nil {
+		return false
+	}
+
+	if c.Redacted {
+		return true
+	}
+
+	for _, v := range []string{c.Name, c.Organization, c.Email} {
+		v = strings.ToLower(v)
+		for _, m := range redactedMarkers {
+			if strings.Contains(v, m) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// DisplayName returns the label of contact, which is the organization if any, otherwise the name or the ID
+func (c *Contact) DisplayName() string {
+	if c == nil {
+		return ""
+	}
+
+	if c.Organization != "" {
+		return c.Organization
+	}
+
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return c.ID
+}
+
+// IsEmpty returns if contact has none of the significant data, the name, organization, email, phone and street,
+// so the section can be skipped when rendering
+func (c *Contact) IsEmpty() bool {
+	if c == nil {
+		return true
+	}
+
+	for _, v := range []string{c.Name, c.Organization, c.Email, c.Phone, c.Street} {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContactsIdentical returns if the contacts of roles a and b are equal field for field,
+// like the admin and tech repeating the same data, for showing them once in a compact display.
+// The role is one of ContactRoles, a missing contact or an unknown role is never identical
+func (w WhoisInfo) ContactsIdentical(a, b string) bool {
+	ca, cb := w.ContactByRole(a), w.ContactByRole(b)
+	if ca == nil || cb == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(*ca, *cb)
+}
+
+// ContactByRole returns the contact of role for iterating over ContactRoles,
+// the short names admin, tech and bill are accepted, an unknown role returns nil
+func (w *WhoisInfo) ContactByRole(role string) *Contact {
+	if w == nil {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case RoleRegistrar:
+		return w.Registrar
+	case RoleRegistrant:
+		return w.Registrant
+	case RoleAdministrative, "admin":
+		return w.Administrative
+	case RoleTechnical, "tech":
+		return w.Technical
+	case RoleBilling, "bill":
+		return w.Billing
+	default:
+		return nil
+	}
+}
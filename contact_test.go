@@ -0,0 +1,198 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestContactIsRedacted(t *testing.T) {
+	// holder and admin share one anonymized block
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/fr_example-anonyme.fr")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.NotZero(t, whoisInfo.Registrant)
+	assert.Equal(t, whoisInfo.Registrant.ID, "ANO00-FRNIC")
+	assert.Equal(t, whoisInfo.Registrant.Name, "")
+	assert.True(t, whoisInfo.Registrant.IsRedacted())
+	assert.NotZero(t, whoisInfo.Administrative)
+	assert.Equal(t, whoisInfo.Administrative.ID, "ANO00-FRNIC")
+	assert.True(t, whoisInfo.Administrative.IsRedacted())
+	assert.Equal(t, whoisInfo.Technical.Name, "GANDI ROLE")
+	assert.False(t, whoisInfo.Technical.IsRedacted())
+	assert.False(t, whoisInfo.Registrar.IsRedacted())
+
+	// legacy output with one block per role
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/wf_git.wf")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.ID, "ANO00-FRNIC")
+	assert.True(t, whoisInfo.Registrant.IsRedacted())
+	assert.True(t, whoisInfo.Administrative.IsRedacted())
+	assert.Equal(t, whoisInfo.Technical.ID, "HOTD14-FRNIC")
+	assert.False(t, whoisInfo.Technical.IsRedacted())
+
+	// placeholder values
+	tests := []struct {
+		in  Contact
+		out bool
+	}{
+		{Contact{Name: "REDACTED FOR PRIVACY"}, true},
+		{Contact{Organization: "Data Protected"}, true},
+		{Contact{Name: "Not Disclosed"}, true},
+		{Contact{Email: "Please query the RDDS service of the Registrar of Record. (redacted)"}, true},
+		{Contact{Name: "Example Owner", Email: "owner@example.com"}, false},
+		{Contact{}, false},
+	}
+
+	for _, v := range tests {
+		assert.Equal(t, v.in.IsRedacted(), v.out, v.in)
+	}
+
+	var contact *Contact
+	assert.False(t, contact.IsRedacted())
+}
+
+func TestContactDisplayName(t *testing.T) {
+	tests := []struct {
+		in  Contact
+		out string
+	}{
+		{Contact{ID: "C1", Name: "Example Owner", Organization: "Example Inc."}, "Example Inc."},
+		{Contact{ID: "C1", Name: "Example Owner"}, "Example Owner"},
+		{Contact{ID: "C1"}, "C1"},
+		{Contact{}, ""},
+	}
+
+	for _, v := range tests {
+		assert.Equal(t, v.in.DisplayName(), v.out, v.in)
+	}
+
+	var contact *Contact
+	assert.Equal(t, contact.DisplayName(), "")
+}
+
+func TestContactIsEmpty(t *testing.T) {
+	tests := []struct {
+		in  Contact
+		out bool
+	}{
+		{Contact{Name: "Example Owner"}, false},
+		{Contact{Organization: "Example Inc."}, false},
+		{Contact{Email: "owner@example.com"}, false},
+		{Contact{Phone: "+1.5551234567"}, false},
+		{Contact{Street: "1 Example Road"}, false},
+		{Contact{ID: "C1", Country: "US", Comment: "example"}, true},
+		{Contact{Name: " ", Email: "\t"}, true},
+		{Contact{}, true},
+	}
+
+	for _, v := range tests {
+		assert.Equal(t, v.in.IsEmpty(), v.out, v.in)
+	}
+
+	var contact *Contact
+	assert.True(t, contact.IsEmpty())
+}
+
+func TestContactsIdentical(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ca_google.ca")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.True(t, whoisInfo.ContactsIdentical("administrative", "technical"))
+	assert.True(t, whoisInfo.ContactsIdentical("Admin", "tech"))
+	assert.False(t, whoisInfo.ContactsIdentical("registrant", "admin"))
+	assert.False(t, whoisInfo.ContactsIdentical("admin", "billing"))
+	assert.False(t, whoisInfo.ContactsIdentical("admin", "owner"))
+
+	whoisInfo.Technical.Email = "other@example.com"
+	assert.False(t, whoisInfo.ContactsIdentical("admin", "tech"))
+}
+
+func TestContactByRole(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-ids.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+
+	contacts := []*Contact{whoisInfo.Registrar, whoisInfo.Registrant, whoisInfo.Administrative,
+		whoisInfo.Technical, whoisInfo.Billing}
+	assert.Equal(t, len(ContactRoles), len(contacts))
+
+	for i, v := range ContactRoles {
+		assert.NotNil(t, whoisInfo.ContactByRole(v), v)
+		assert.True(t, whoisInfo.ContactByRole(v) == contacts[i], v)
+	}
+
+	// the returned pointer updates the whois info
+	whoisInfo.ContactByRole(RoleTechnical).Email = "noc@example.com"
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example.com")
+
+	assert.True(t, whoisInfo.ContactByRole(" Admin ") == whoisInfo.Administrative)
+	assert.True(t, whoisInfo.ContactByRole("tech") == whoisInfo.Technical)
+	assert.True(t, whoisInfo.ContactByRole("bill") == whoisInfo.Billing)
+	assert.True(t, whoisInfo.ContactByRole("owner") == nil)
+	assert.True(t, whoisInfo.ContactByRole("") == nil)
+
+	var nilInfo *WhoisInfo
+	assert.True(t, nilInfo.ContactByRole(RoleRegistrant) == nil)
+}
+
+func TestContactCountryCode(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Country, "US")
+	assert.Equal(t, whoisInfo.Registrant.CountryCode, "US")
+
+	// the country name is kept as is without code
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/fi_git.fi")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Country, "Finland")
+	assert.Equal(t, whoisInfo.Registrant.CountryCode, "")
+
+	tests := map[string]string{
+		"US":            "US",
+		" de ":          "DE",
+		"USA":           "",
+		"United States": "",
+		"U.":            "",
+		"":              "",
+	}
+
+	for k, v := range tests {
+		assert.Equal(t, countryCode(k), v, k)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/likexian/gokit/assert"
+)
+
+// Diff returns the changed domain, contact and extension fields from w to other, for monitoring whois over time.
+//
+// The field of change is the JSON path, like domain.name_servers, registrant.email or extensions[ens_auth_id].
+// Slices like name servers and status are compared as sets and changed with the added and removed values,
+// other fields are changed with the old and new values. Dates are compared by the raw date strings,
+// the parser version, IP and AS info are not compared. A missing contact is the same as an empty one.
+func (w WhoisInfo) Diff(other WhoisInfo) []FieldChange {
+	changes := []FieldChange{}
+
+	changes = diffFields(changes, "domain", reflect.ValueOf(w.Domain), reflect.ValueOf(other.Domain))
+	changes = diffFields(changes, "registrar", reflect.ValueOf(w.Registrar), reflect.ValueOf(other.Registrar))
+	changes = diffFields(changes, "registrant", reflect.ValueOf(w.Registrant), reflect.ValueOf(other.Registrant))
+	changes = diffFields(changes, "administrative",
+		reflect.ValueOf(w.Administrative), reflect.ValueOf(other.Administrative))
+	changes = diffFields(changes, "technical", reflect.ValueOf(w.Technical), reflect.ValueOf(other.Technical))
+	changes = diffFields(changes, "billing", reflect.ValueOf(w.Billing), reflect.ValueOf(other.Billing))
+	changes = diffMap(changes, "extensions", reflect.ValueOf(w.Extensions), reflect.ValueOf(other.Extensions))
+
+	return changes
+}
+
+// diffFields appends the changes of struct fields from before to after, both are pointers to struct
+func diffFields(changes []FieldChange, path string, before, after reflect.Value) []FieldChange {
+	if before.IsNil() {
+		before = reflect.New(before.Type().Elem())
+	}
+
+	if after.IsNil() {
+		after = reflect.New(after.Type().Elem())
+	}
+
+	before, after = before.Elem(), after.Elem()
+	for i := 0; i < before.NumField(); i++ {
+		name := path + "." + strings.Split(before.Type().Field(i).Tag.Get("json"), ",")[0]
+		o, n := before.Field(i), after.Field(i)
+		switch o.Kind() {
+		case reflect.String:
+			if o.String() != n.String() {
+				changes = append(changes, FieldChange{Field: name, Old: o.String(), New: n.String()})
+			}
+		case reflect.Bool:
+			if o.Bool() != n.Bool() {
+				changes = append(changes, FieldChange{Field: name,
+					Old: strconv.FormatBool(o.Bool()), New: strconv.FormatBool(n.Bool())})
+			}
+		case reflect.Slice:
+			changes = diffSlice(changes, name, o.Interface().([]string), n.Interface().([]string))
+		case reflect.Map:
+			changes = diffMap(changes, name, o, n)
+		}
+	}
+
+	return changes
+}
+
+// diffMap appends the changes of map values from before to after, keys are in order
+func diffMap(changes []FieldChange, path string, before, after reflect.Value) []FieldChange {
+	keys := []string{}
+	for _, m := range []reflect.Value{before, after} {
+		for _, k := range m.MapKeys() {
+			if !assert.IsContains(keys, k.String()) {
+				keys = append(keys, k.String())
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := path + "[" + k + "]"
+		o, n := before.MapIndex(reflect.ValueOf(k)), after.MapIndex(reflect.ValueOf(k))
+		if before.Type().Elem().Kind() == reflect.Slice {
+			os, ns := []string{}, []string{}
+			if o.IsValid() {
+				os = o.Interface().([]string)
+			}
+			if n.IsValid() {
+				ns = n.Interface().([]string)
+			}
+			changes = diffSlice(changes, name, os, ns)
+			continue
+		}
+
+		os, ns := "", ""
+		if o.IsValid() {
+			os = o.String()
+		}
+		if n.IsValid() {
+			ns = n.String()
+		}
+		if os != ns {
+			changes = append(changes, FieldChange{Field: name, Old: os, New: ns})
+		}
+	}
+
+	return changes
+}
+
+// diffSlice appends the change of slice values from before to after as a set, with the added and removed values
+func diffSlice(changes []FieldChange, path string, before, after []string) []FieldChange {
+	var added []string
+	for _, v := range after {
+		if !assert.IsContains(before, v) && !assert.IsContains(added, v) {
+			added = append(added, v)
+		}
+	}
+
+	var removed []string
+	for _, v := range before {
+		if !assert.IsContains(after, v) && !assert.IsContains(removed, v) {
+			removed = append(removed, v)
+		}
+	}
+
+	if len(added)+len(removed) > 0 {
+		changes = append(changes, FieldChange{Field: path, Added: added, Removed: removed})
+	}
+
+	return changes
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func TestDiff(t *testing.T) {
+	before, err := Parse(`Domain Name: EXAMPLE.COM
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Registrar WHOIS Server: whois.example-registrar.com
+Updated Date: 2023-08-14T07:01:38Z
+Creation Date: 1995-08-14T04:00:00Z
+Registry Expiry Date: 2024-08-13T04:00:00Z
+Registrar: Example Registrar, Inc.
+Registrar IANA ID: 376
+Domain Status: clientDeleteProhibited https://icann.org/epp#clientDeleteProhibited
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Registrant Organization: Example Inc.
+Registrant Email: hostmaster@example.com
+Name Server: A.IANA-SERVERS.NET
+Name Server: B.IANA-SERVERS.NET
+DNSSEC: signedDelegation`)
+	assert.Nil(t, err)
+
+	after, err := Parse(`Domain Name: EXAMPLE.COM
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Registrar WHOIS Server: whois.example-registrar.com
+Updated Date: 2024-08-14T07:01:38Z
+Creation Date: 1995-08-14T04:00:00Z
+Registry Expiry Date: 2025-08-13T04:00:00Z
+Registrar: Example Registrar, Inc.
+Registrar IANA ID: 376
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Domain Status: clientUpdateProhibited https://icann.org/epp#clientUpdateProhibited
+Registrant Organization: Example Inc.
+Registrant Email: dns-admin@example.com
+Name Server: B.IANA-SERVERS.NET
+Name Server: C.IANA-SERVERS.NET
+DNSSEC: signedDelegation`)
+	assert.Nil(t, err)
+
+	assert.Equal(t, before.Diff(before), []FieldChange{})
+	assert.Equal(t, before.Diff(after), []FieldChange{
+		{Field: "domain.status", Added: []string{"clientUpdateProhibited"},
+			Removed: []string{"clientDeleteProhibited"}},
+		{Field: "domain.name_servers", Added: []string{"c.iana-servers.net"},
+			Removed: []string{"a.iana-servers.net"}},
+		{Field: "domain.updated_date", Old: "2023-08-14T07:01:38Z", New: "2024-08-14T07:01:38Z"},
+		{Field: "domain.expiration_date", Old: "2024-08-13T04:00:00Z", New: "2025-08-13T04:00:00Z"},
+		{Field: "registrant.email", Old: "hostmaster@example.com", New: "dns-admin@example.com"},
+		{Field: "registrant.emails", Added: []string{"dns-admin@example.com"},
+			Removed: []string{"hostmaster@example.com"}},
+	})
+
+	// order of slice values is not a change
+	after.Domain.Status = []string{"clientUpdateProhibited", "clientTransferProhibited"}
+	assert.Equal(t, len(before.Diff(after)), 6)
+
+	// missing contact is the same as an empty one, then the changes are set
+	after.Registrant = nil
+	after.Technical = &Contact{Email: "tech@example.com"}
+	changes := before.Diff(after)
+	assert.Equal(t, changes[4:], []FieldChange{
+		{Field: "registrant.organization", Old: "Example Inc."},
+		{Field: "registrant.email", Old: "hostmaster@example.com"},
+		{Field: "registrant.emails", Removed: []string{"hostmaster@example.com"}},
+		{Field: "technical.email", New: "tech@example.com"},
+	})
+}
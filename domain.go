@@ -0,0 +1,215 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/likexian/gokit/assert"
+)
+
+// IsExpired returns if domain is past its expiration date or in a grace period after it,
+// the grace period status are autoRenewPeriod, redemptionPeriod, pendingRestore and pendingDelete
+func (d *Domain) IsExpired() bool {
+	if d == nil {
+		return false
+	}
+
+	if d.hasStatus("autoRenewPeriod", "redemptionPeriod", "pendingRestore", "pendingDelete") {
+		return true
+	}
+
+	return d.ExpirationDateInTime != nil && d.ExpirationDateInTime.Before(time.Now())
+}
+
+// InRedemption returns if domain is in the redemption grace period
+func (d *Domain) InRedemption() bool {
+	if d == nil {
+		return false
+	}
+
+	return d.hasStatus("redemptionPeriod", "pendingRestore")
+}
+
+// HasTransferLock returns if domain is locked against transfer by the registrar or the registry
+func (d *Domain) HasTransferLock() bool {
+	if d == nil {
+		return false
+	}
+
+	return d.hasStatus("clientTransferProhibited", "serverTransferProhibited")
+}
+
+// HasDeleteLock returns if domain is locked against deletion by the registrar or the registry
+func (d *Domain) HasDeleteLock() bool {
+	if d == nil {
+		return false
+	}
+
+	return d.hasStatus("clientDeleteProhibited", "serverDeleteProhibited")
+}
+
+// ShouldFollowReferral returns if the registrar whois server is worth querying from the current server,
+// it is false when there is no registrar whois server or it points back to the current server
+func (d *Domain) ShouldFollowReferral(current string) bool {
+	if d == nil {
+		return false
+	}
+
+	server := d.RegistrarWhoisServer
+	if server == "" {
+		server = d.WhoisServer
+	}
+
+	server = whoisServerKey(server)
+
+	return server != "" && server != whoisServerKey(current)
+}
+
+// The registry types of whois response returned by WhoisInfo.RegistryType
+const (
+	RegistryTypeUnknown = "unknown"
+	RegistryTypeThin    = "thin"
+	RegistryTypeThick   = "thick"
+)
+
+// RegistryType returns if the whois response is from a thin registry, which has no contact data
+// but refers to the registrar whois server, or a thick one, the registrar or ccTLD with the full record.
+// A response with any registrant, admin, tech or billing data is thick, a response with neither the contact
+// nor the referral is thick if the registrar is known, like .uk hiding the registrant, otherwise unknown
+func (w WhoisInfo) RegistryType() string {
+	if w.Domain == nil {
+		return RegistryTypeUnknown
+	}
+
+	for _, v := range []*Contact{w.Registrant, w.Administrative, w.Technical, w.Billing} {
+		if !v.IsEmpty() {
+			return RegistryTypeThick
+		}
+	}
+
+	if whoisServerKey(w.Domain.RegistrarWhoisServer) != "" || whoisServerKey(w.Domain.WhoisServer) != "" {
+		return RegistryTypeThin
+	}
+
+	if w.Registrar != nil {
+		return RegistryTypeThick
+	}
+
+	return RegistryTypeUnknown
+}
+
+// whoisServerKey returns the host of whois server for comparing,
+// scheme, port, path, trailing dot and case are removed
+func whoisServerKey(server string) string {
+	server = strings.ToLower(strings.TrimSpace(server))
+	if i := strings.Index(server, "://"); i != -1 {
+		server = server[i+3:]
+	}
+	if i := strings.IndexAny(server, "/:"); i != -1 {
+		server = server[:i]
+	}
+
+	return strings.TrimSuffix(server, ".")
+}
+
+// eppStatus is the EPP domain status codes, with the nearest code of prose status seen in ccTLD responses
+var eppStatus = map[string]string{
+	"ok":                       "ok",
+	"inactive":                 "inactive",
+	"clienthold":               "clientHold",
+	"serverhold":               "serverHold",
+	"clientdeleteprohibited":   "clientDeleteProhibited",
+	"serverdeleteprohibited":   "serverDeleteProhibited",
+	"clientrenewprohibited":    "clientRenewProhibited",
+	"serverrenewprohibited":    "serverRenewProhibited",
+	"clienttransferprohibited": "clientTransferProhibited",
+	"servertransferprohibited": "serverTransferProhibited",
+	"clientupdateprohibited":   "clientUpdateProhibited",
+	"serverupdateprohibited":   "serverUpdateProhibited",
+	"pendingcreate":            "pendingCreate",
+	"pendingdelete":            "pendingDelete",
+	"pendingrenew":             "pendingRenew",
+	"pendingrestore":           "pendingRestore",
+	"pendingtransfer":          "pendingTransfer",
+	"pendingupdate":            "pendingUpdate",
+	"addperiod":                "addPeriod",
+	"autorenewperiod":          "autoRenewPeriod",
+	"renewperiod":              "renewPeriod",
+	"redemptionperiod":         "redemptionPeriod",
+	"transferperiod":           "transferPeriod",
+	"active":                   "ok",
+	"registered":               "ok",
+	"taken":                    "ok",
+	"connect":                  "ok",
+	"connected":                "ok",
+	"delegated":                "ok",
+	"published":                "ok",
+	"verified":                 "ok",
+	"notdelegated":             "inactive",
+	"hold":                     "serverHold",
+	"onhold":                   "serverHold",
+	"suspended":                "serverHold",
+	"registrarlock":            "clientTransferProhibited",
+	"redemption":               "redemptionPeriod",
+	"tobesuspended":            "redemptionPeriod",
+}
+
+// NormalizedStatus returns the status with known prose status mapped to the nearest EPP status,
+// like ACTIVE and Registered to ok and suspended to serverHold, unknown status are kept as is.
+// The raw status is not changed, duplicates after mapping are removed.
+func (d *Domain) NormalizedStatus() []string {
+	if d == nil || len(d.Status) == 0 {
+		return nil
+	}
+
+	result := []string{}
+	for _, v := range d.Status {
+		if s, ok := eppStatus[statusKey(v)]; ok {
+			v = s
+		}
+		if !assert.IsContains(result, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// hasStatus returns if domain has any of the status, EPP and prose forms are treated the same
+func (d *Domain) hasStatus(status ...string) bool {
+	for _, v := range d.Status {
+		v = statusKey(v)
+		for _, s := range status {
+			if v == statusKey(s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// statusKey returns the status in lower case without spaces, hyphens and underscores,
+// so "Pending Delete" and "PENDING_DELETE" equal "pendingDelete"
+func statusKey(status string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(status))
+}
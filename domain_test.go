@@ -0,0 +1,193 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestDomainIsExpired(t *testing.T) {
+	// expired-example.com in redemption period
+	whoisInfo, err := Parse(`Domain Name: expired-example.com
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Updated Date: 2024-03-02T10:21:45Z
+Creation Date: 2015-01-01T00:00:00Z
+Registry Expiry Date: 2099-01-01T00:00:00Z
+Domain Status: redemptionPeriod https://icann.org/epp#redemptionPeriod
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited`)
+	assert.Nil(t, err)
+	assert.True(t, whoisInfo.Domain.IsExpired())
+	assert.True(t, whoisInfo.Domain.InRedemption())
+
+	// prose status form
+	whoisInfo, err = Parse(`Domain Name: expired-example.nu
+Status: Pending Delete
+Expires: 2099-01-01`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Pending Delete"})
+	assert.True(t, whoisInfo.Domain.IsExpired())
+	assert.False(t, whoisInfo.Domain.InRedemption())
+
+	whoisInfo, err = Parse(`Domain Name: expired-example.nu
+Status: Redemption Period
+Expires: 2099-01-01`)
+	assert.Nil(t, err)
+	assert.True(t, whoisInfo.Domain.InRedemption())
+
+	// expiration date in the past
+	whoisInfo, err = Parse(`Domain Name: expired-example.com
+Domain Status: ok https://icann.org/epp#ok
+Registry Expiry Date: 2001-01-01T00:00:00Z`)
+	assert.Nil(t, err)
+	assert.True(t, whoisInfo.Domain.IsExpired())
+	assert.False(t, whoisInfo.Domain.InRedemption())
+
+	whoisInfo, err = Parse(`Domain Name: active-example.com
+Domain Status: ok https://icann.org/epp#ok
+Registry Expiry Date: 2099-01-01T00:00:00Z`)
+	assert.Nil(t, err)
+	assert.False(t, whoisInfo.Domain.IsExpired())
+	assert.False(t, whoisInfo.Domain.InRedemption())
+
+	var domain *Domain
+	assert.False(t, domain.IsExpired())
+	assert.False(t, domain.InRedemption())
+}
+
+func TestDomainHasLock(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.True(t, whoisInfo.Domain.HasTransferLock())
+	assert.True(t, whoisInfo.Domain.HasDeleteLock())
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/name_github.name")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.False(t, whoisInfo.Domain.HasTransferLock())
+	assert.False(t, whoisInfo.Domain.HasDeleteLock())
+
+	// prose status form
+	domain := &Domain{Status: []string{"Server Transfer Prohibited"}}
+	assert.True(t, domain.HasTransferLock())
+	assert.False(t, domain.HasDeleteLock())
+
+	domain = &Domain{Status: []string{"serverDeleteProhibited"}}
+	assert.False(t, domain.HasTransferLock())
+	assert.True(t, domain.HasDeleteLock())
+
+	// prose status of parsed whois
+	whoisInfo, err = Parse("Domain Name: example.com\nStatus: Server Transfer Prohibited\n" +
+		"Status: client delete prohibited\n")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Server Transfer Prohibited", "client delete prohibited"})
+	assert.True(t, whoisInfo.Domain.HasTransferLock())
+	assert.True(t, whoisInfo.Domain.HasDeleteLock())
+
+	domain = nil
+	assert.False(t, domain.HasTransferLock())
+	assert.False(t, domain.HasDeleteLock())
+}
+
+func TestDomainNormalizedStatus(t *testing.T) {
+	domain := &Domain{Status: []string{"ACTIVE"}}
+	assert.Equal(t, domain.NormalizedStatus(), []string{"ok"})
+	assert.Equal(t, domain.Status, []string{"ACTIVE"})
+
+	domain = &Domain{Status: []string{"Registered", "OK", "connect"}}
+	assert.Equal(t, domain.NormalizedStatus(), []string{"ok"})
+
+	domain = &Domain{Status: []string{"suspended", "Registrar-Lock"}}
+	assert.Equal(t, domain.NormalizedStatus(), []string{"serverHold", "clientTransferProhibited"})
+
+	domain = &Domain{Status: []string{"CLIENT_TRANSFER_PROHIBITED", "Pending Delete", "not delegated"}}
+	assert.Equal(t, domain.NormalizedStatus(), []string{"clientTransferProhibited", "pendingDelete", "inactive"})
+
+	// unknown status are kept as is
+	domain = &Domain{Status: []string{"clientUpdateProhibited", "UNVERIFIED", "200"}}
+	assert.Equal(t, domain.NormalizedStatus(), []string{"clientUpdateProhibited", "UNVERIFIED", "200"})
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NormalizedStatus(), whoisInfo.Domain.Status)
+
+	domain = nil
+	assert.Zero(t, domain.NormalizedStatus())
+}
+
+func TestDomainShouldFollowReferral(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.RegistrarWhoisServer, "whois.markmonitor.com")
+	assert.True(t, whoisInfo.Domain.ShouldFollowReferral("whois.verisign-grs.com"))
+
+	// the registrar whois server points back to the current server
+	assert.False(t, whoisInfo.Domain.ShouldFollowReferral("whois.markmonitor.com"))
+	assert.False(t, whoisInfo.Domain.ShouldFollowReferral("WHOIS.MarkMonitor.com."))
+	assert.False(t, whoisInfo.Domain.ShouldFollowReferral("whois://whois.markmonitor.com:43"))
+
+	domain := &Domain{RegistrarWhoisServer: "whois://whois.example-registrar.com/"}
+	assert.False(t, domain.ShouldFollowReferral("whois.example-registrar.com"))
+	assert.True(t, domain.ShouldFollowReferral("whois.example.com"))
+
+	// whois server is used when there is no registrar whois server
+	domain = &Domain{WhoisServer: "whois.example-registrar.com"}
+	assert.False(t, domain.ShouldFollowReferral("whois.example-registrar.com"))
+	assert.True(t, domain.ShouldFollowReferral(""))
+
+	domain = &Domain{}
+	assert.False(t, domain.ShouldFollowReferral("whois.example.com"))
+
+	domain = nil
+	assert.False(t, domain.ShouldFollowReferral("whois.example.com"))
+}
+
+func TestWhoisInfoRegistryType(t *testing.T) {
+	tests := map[string]string{
+		"com_example-thin.com": RegistryTypeThin,
+		"com_google.com":       RegistryTypeThick,
+		"uk_google.uk":         RegistryTypeThick,
+	}
+
+	for k, v := range tests {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err, k)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, k)
+		assert.Equal(t, whoisInfo.RegistryType(), v, k)
+	}
+
+	assert.Equal(t, WhoisInfo{}.RegistryType(), RegistryTypeUnknown)
+	assert.Equal(t, WhoisInfo{Domain: &Domain{Domain: "example.de"}}.RegistryType(), RegistryTypeUnknown)
+}
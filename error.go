@@ -34,6 +34,8 @@ var (
 	ErrPremiumDomain = errors.New("whoisparser: domain is available at premium price")
 	// ErrBlockedDomain domain is blocked due to brand protection
 	ErrBlockedDomain = errors.New("whoisparser: domain is blocked due to brand protection")
+	// ErrInvalidDomain domain name is invalid to register
+	ErrInvalidDomain = errors.New("whoisparser: domain name is invalid")
 	// ErrDomainDataInvalid domain whois data is invalid
 	ErrDomainDataInvalid = errors.New("whoisparser: domain whois data is invalid")
 	// ErrDomainLimitExceed domain whois query is limited
@@ -50,8 +52,50 @@ var (
 	ErrASDataInvalid = errors.New("whoisparser: AS whois data is invalid")
 	// ErrASLimitExceed AS whois query is limited
 	ErrASLimitExceed = errors.New("whoisparser: AS whois query limit exceeded")
+	// ErrMissingFields expected whois fields are missing
+	ErrMissingFields = errors.New("whoisparser: expected fields are missing")
+	// ErrGzipTooLarge gzip-compressed whois is too large after decompression
+	ErrGzipTooLarge = errors.New("whoisparser: gzip-compressed whois is too large")
+	// ErrLineTooLong whois line is too long to be parsed
+	ErrLineTooLong = errors.New("whoisparser: whois line is too long")
+	// ErrDomainMismatch parsed domain is not the expected one
+	ErrDomainMismatch = errors.New("whoisparser: parsed domain is not the expected one")
+	// ErrUnknownContactRole contact role is not one of the parsed contact roles
+	ErrUnknownContactRole = errors.New("whoisparser: contact role is unknown")
 )
 
+// MissingFieldsError is returned by ParseStrict if expected fields are missing, it matches ErrMissingFields
+type MissingFieldsError struct {
+	Fields []string
+}
+
+// Error returns the error message with the missing fields
+func (e *MissingFieldsError) Error() string {
+	return ErrMissingFields.Error() + ": " + strings.Join(e.Fields, ", ")
+}
+
+// Unwrap returns ErrMissingFields
+func (e *MissingFieldsError) Unwrap() error {
+	return ErrMissingFields
+}
+
+// DomainMismatchError is returned by Parse if the parsed domain is not the one set by WithExpectedDomain,
+// it matches ErrDomainMismatch
+type DomainMismatchError struct {
+	Expected string
+	Parsed   string
+}
+
+// Error returns the error message with the expected and parsed domain
+func (e *DomainMismatchError) Error() string {
+	return ErrDomainMismatch.Error() + ": expected " + e.Expected + ", parsed " + e.Parsed
+}
+
+// Unwrap returns ErrDomainMismatch
+func (e *DomainMismatchError) Unwrap() error {
+	return ErrDomainMismatch
+}
+
 // getErrorType returns error type of whois data
 func getErrorType(data string) error {
 	if isASWhois(data) {
@@ -104,6 +148,28 @@ func getASErrorType(data string) error {
 	}
 }
 
+var whoisErrorRx = regexp.MustCompile(`(?i)^%\s*error:\s*(\d+)`)
+
+// searchWhoisError returns the "%ERROR:101: no entries found" line of RIPE style servers, empty if there is none
+func searchWhoisError(data string) string {
+	for _, v := range strings.Split(data, "\n") {
+		if v = strings.TrimSpace(v); whoisErrorRx.MatchString(v) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// getWhoisErrorType returns error type of the RIPE style error line, the code 101 is no entries found
+func getWhoisErrorType(line string) error {
+	if m := whoisErrorRx.FindStringSubmatch(line); len(m) > 1 && m[1] == "101" {
+		return ErrNotFoundDomain
+	}
+
+	return getDomainErrorType(line)
+}
+
 // isNotFoundDomain returns if domain is not found
 func isNotFoundDomain(data string) bool {
 	notFoundKeys := []string{
@@ -127,6 +193,27 @@ func isNotFoundDomain(data string) bool {
 	return containsIn(strings.ToLower(data), notFoundKeys)
 }
 
+// isNoMatchDomain returns if whois data is the Verisign style not found response,
+// like .com, .net, .tv and .cc, which has no domain name field to search,
+// the leading comments are skipped and the suggested alternatives after it are ignored
+func isNoMatchDomain(data string) bool {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "%") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.HasPrefix(line, "no match for ")
+	}
+
+	return false
+}
+
+// isFreenomNotFoundDomain returns if whois data is the Freenom not found response,
+// like .tk, .ml, .ga, .cf and .gq, which names the registry instead of the domain
+func isFreenomNotFoundDomain(data string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(data)), "invalid query or domain name not known")
+}
+
 // isNotFoundIP returns if IP address is not found
 func isNotFoundIP(data string) bool {
 	notFoundKeys := []string{
@@ -159,10 +246,22 @@ func isExtNotFoundDomain(data, extension string) bool {
 	data = reBlank.ReplaceAllString(data, " ")
 
 	switch extension {
-	case "ai", "cx", "gs":
+	case "ai", "cx", "gs", "gl":
 		if strings.Contains(data, "Domain Status: No Object Found") {
 			return true
 		}
+	case "ad":
+		if strings.Contains(data, "Status: Free") {
+			return true
+		}
+	case "mc":
+		if strings.Contains(data, "Status: Not Registered") {
+			return true
+		}
+	case "sm":
+		if strings.Contains(data, "Status: Available") {
+			return true
+		}
 	case "de":
 		if strings.Contains(data, "Status: free") {
 			return true
@@ -179,6 +278,10 @@ func isExtNotFoundDomain(data, extension string) bool {
 		if strings.Contains(data, "No information available about domain name") {
 			return true
 		}
+	case "ph":
+		if strings.Contains(data, "is available for registration") {
+			return true
+		}
 	case "sexy":
 		if strings.Contains(data, "is available") {
 			return true
@@ -198,6 +301,20 @@ func isExtNotFoundDomain(data, extension string) bool {
 	return false
 }
 
+// isExtInvalidDomain returns if domain name is invalid by extension, like the .de "Status: invalid"
+func isExtInvalidDomain(data, extension string) bool {
+	data = reBlank.ReplaceAllString(data, " ")
+
+	switch extension {
+	case "de":
+		if strings.Contains(data, "Status: invalid") {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isReservedDomain returns if domain is reserved
 func isReservedDomain(data string) bool {
 	reservedKeys := []string{
@@ -246,6 +363,7 @@ func isLimitExceeded(data string) bool {
 		"you have exceeded your allotted number of",
 		"maximum daily connection limit reached",
 		"maximum query rate reached",
+		"access control limit reached",
 	}
 
 	return containsIn(strings.ToLower(data), limitExceedKeys)
@@ -54,8 +54,17 @@ func TestAsisExtNotFoundDomain(t *testing.T) {
 		whoisRaw, err := xfile.ReadText(notfoundDir + "/" + v.Name)
 		assert.Nil(t, err)
 
-		_, extension := searchDomain(whoisRaw)
-		if extension == "" {
+		if isTonicWhois(whoisRaw) {
+			whoisRaw = prepareTO(whoisRaw)
+		}
+
+		if line := searchWhoisError(whoisRaw); line != "" {
+			assert.Equal(t, getWhoisErrorType(line), ErrNotFoundDomain, v.Name)
+			continue
+		}
+
+		_, extension := searchDomain(stripWhoisComments(whoisRaw))
+		if extension == "" || isNoMatchDomain(whoisRaw) {
 			assert.True(t, isNotFoundDomain(whoisRaw), v.Name)
 		} else {
 			assert.True(t, isExtNotFoundDomain(whoisRaw, extension), v.Name)
@@ -138,3 +147,33 @@ func TestAsisLimitExceeded(t *testing.T) {
 	data = `%% Maximum query rate reached`
 	assert.True(t, isLimitExceeded(data))
 }
+
+func TestAsisNoMatchDomain(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(notfoundDir + "/net_likexian-no-match-suggestions.net")
+	assert.Nil(t, err)
+	assert.True(t, isNoMatchDomain(whoisRaw))
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+	assert.Zero(t, whoisInfo.Domain)
+
+	for _, v := range []string{"tv", "cc"} {
+		whoisRaw, err := xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		assert.True(t, isNoMatchDomain(whoisRaw), v)
+
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+
+		for _, name := range []string{"google", "msn"} {
+			whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v + "_" + name + "." + v)
+			assert.Nil(t, err)
+			assert.False(t, isNoMatchDomain(whoisRaw), v)
+
+			whoisInfo, err := Parse(whoisRaw)
+			assert.Nil(t, err)
+			assert.Equal(t, whoisInfo.Domain.Domain, name+"."+v)
+			assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// maxGzipSize is the max size of decompressed whois, a whois response is far less than it
+const maxGzipSize = 16 << 20
+
+// ParseGzip returns parsed whois info like Parse from gzip-compressed data, for whois archives,
+// data without the gzip magic bytes is parsed as is, ErrGzipTooLarge is returned if the decompressed is too large
+func ParseGzip(data []byte, opts ...Option) (whoisInfo WhoisInfo, err error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return Parse(string(data), opts...)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	text, err := io.ReadAll(io.LimitReader(reader, maxGzipSize+1))
+	if err != nil {
+		return
+	}
+
+	if len(text) > maxGzipSize {
+		err = ErrGzipTooLarge
+		return
+	}
+
+	return Parse(string(text), opts...)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestParseGzip(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	var data bytes.Buffer
+	writer := gzip.NewWriter(&data)
+	_, err = writer.Write([]byte(whoisRaw))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	expected, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+
+	whoisInfo, err := ParseGzip(data.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo, expected)
+
+	// options are applied
+	whoisInfo, err = ParseGzip(data.Bytes(), WithSorted())
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers,
+		[]string{"ns1.google.com", "ns2.google.com", "ns3.google.com", "ns4.google.com"})
+
+	// not compressed
+	whoisInfo, err = ParseGzip([]byte(whoisRaw))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo, expected)
+
+	// not found domain
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/com_likexian-have-no-money-to-register.com")
+	assert.Nil(t, err)
+
+	data.Reset()
+	writer = gzip.NewWriter(&data)
+	_, err = writer.Write([]byte(whoisRaw))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	_, err = ParseGzip(data.Bytes())
+	assert.Equal(t, err, ErrNotFoundDomain)
+
+	// broken data
+	_, err = ParseGzip(data.Bytes()[:20])
+	assert.NotNil(t, err)
+
+	_, err = ParseGzip([]byte{0x1f, 0x8b, 0x00})
+	assert.NotNil(t, err)
+
+	// decompression bomb
+	data.Reset()
+	writer = gzip.NewWriter(&data)
+	_, err = writer.Write(make([]byte, maxGzipSize+1))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	_, err = ParseGzip(data.Bytes())
+	assert.Equal(t, err, ErrGzipTooLarge)
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"reflect"
+)
+
+// Merge returns the whois info combined from a thin registry lookup and the following registrar lookup.
+//
+// The precedence is:
+//   - Domain fields come from the registry, which is authoritative for status, dates and name servers,
+//     the fields that are empty in the registry are filled from the registrar.
+//   - Contact fields come from the registrar when not empty, otherwise from the registry.
+//   - IP and AS info come from the registry, or from the registrar if the registry has none.
+//   - Extensions are combined, the registry wins on conflicting keys.
+//
+// Neither of the inputs is modified, the result shares no pointers or slices with them.
+func Merge(registry, registrar WhoisInfo) WhoisInfo {
+	result := WhoisInfo{
+		Domain:         mergeDomain(registry.Domain, registrar.Domain),
+		Registrar:      mergeContact(registry.Registrar, registrar.Registrar),
+		Registrant:     mergeContact(registry.Registrant, registrar.Registrant),
+		Administrative: mergeContact(registry.Administrative, registrar.Administrative),
+		Technical:      mergeContact(registry.Technical, registrar.Technical),
+		Billing:        mergeContact(registry.Billing, registrar.Billing),
+		IP:             copyIP(registry.IP),
+		AS:             copyAS(registry.AS),
+		ParserVersion:  registry.ParserVersion,
+	}
+
+	if result.IP == nil {
+		result.IP = copyIP(registrar.IP)
+	}
+
+	if result.AS == nil {
+		result.AS = copyAS(registrar.AS)
+	}
+
+	if len(registry.Extensions)+len(registrar.Extensions) > 0 {
+		result.Extensions = map[string]string{}
+		for k, v := range registrar.Extensions {
+			result.Extensions[k] = v
+		}
+		for k, v := range registry.Extensions {
+			result.Extensions[k] = v
+		}
+	}
+
+	if result.ParserVersion == "" {
+		result.ParserVersion = registrar.ParserVersion
+	}
+
+	return result
+}
+
+// mergeDomain returns domain of registry with empty fields filled from registrar
+func mergeDomain(registry, registrar *Domain) *Domain {
+	if registry == nil && registrar == nil {
+		return nil
+	}
+
+	domain := &Domain{}
+	if registrar != nil {
+		mergeFields(reflect.ValueOf(domain).Elem(), reflect.ValueOf(registrar).Elem())
+	}
+
+	if registry != nil {
+		mergeFields(reflect.ValueOf(domain).Elem(), reflect.ValueOf(registry).Elem())
+	}
+
+	// unknown dnssec status of registry is taken as empty, the stated status of registrar is kept
+	if registry != nil && registry.DNSSECStatus == DNSSECStatusUnknown && registrar != nil &&
+		registrar.DNSSECStatus != "" {
+		domain.DNSSECStatus = registrar.DNSSECStatus
+	}
+
+	return domain
+}
+
+// mergeContact returns contact of registry with fields overridden by non-empty fields of registrar
+func mergeContact(registry, registrar *Contact) *Contact {
+	if registry == nil && registrar == nil {
+		return nil
+	}
+
+	contact := &Contact{}
+	if registry != nil {
+		mergeFields(reflect.ValueOf(contact).Elem(), reflect.ValueOf(registry).Elem())
+	}
+
+	if registrar != nil {
+		mergeFields(reflect.ValueOf(contact).Elem(), reflect.ValueOf(registrar).Elem())
+	}
+
+	return contact
+}
+
+// copyIP returns a copy of ip info sharing no pointers or slices with it
+func copyIP(ip *IPInfo) *IPInfo {
+	if ip == nil {
+		return nil
+	}
+
+	result := &IPInfo{
+		Abuse:     mergeContact(ip.Abuse, nil),
+		Technical: mergeContact(ip.Technical, nil),
+		Routing:   mergeContact(ip.Routing, nil),
+	}
+
+	for _, v := range ip.Networks {
+		if v == nil {
+			result.Networks = append(result.Networks, nil)
+			continue
+		}
+
+		network := *v
+		if v.CIDR != nil {
+			network.CIDR = append([]string{}, v.CIDR...)
+		}
+		network.Organization = mergeContact(v.Organization, nil)
+		network.Customer = mergeContact(v.Customer, nil)
+		result.Networks = append(result.Networks, &network)
+	}
+
+	return result
+}
+
+// copyAS returns a copy of as info sharing no pointers or slices with it
+func copyAS(as *ASInfo) *ASInfo {
+	if as == nil {
+		return nil
+	}
+
+	result := *as
+	result.Organization = mergeContact(as.Organization, nil)
+	result.Routing = mergeContact(as.Routing, nil)
+	result.Technical = mergeContact(as.Technical, nil)
+	result.Abuse = mergeContact(as.Abuse, nil)
+
+	return &result
+}
+
+// mergeFields copies the non-empty fields of src struct to dst struct, slices and maps are copied
+func mergeFields(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if field.IsZero() {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Slice:
+			if field.Len() > 0 {
+				dst.Field(i).Set(reflect.AppendSlice(reflect.MakeSlice(field.Type(), 0, field.Len()), field))
+			}
+		case reflect.Map:
+			value := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for _, k := range field.MapKeys() {
+				v := field.MapIndex(k)
+				if v.Kind() == reflect.Slice {
+					v = reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v)
+				}
+				value.SetMapIndex(k, v)
+			}
+			dst.Field(i).Set(value)
+		case reflect.Ptr:
+			value := reflect.New(field.Type().Elem())
+			value.Elem().Set(field.Elem())
+			dst.Field(i).Set(value)
+		default:
+			dst.Field(i).Set(field)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestMerge(t *testing.T) {
+	// thin registry response of google.com
+	registry, err := Parse(`Domain Name: GOOGLE.COM
+Registry Domain ID: 2138514_DOMAIN_COM-VRSN
+Registrar WHOIS Server: whois.markmonitor.com
+Registrar URL: http://www.markmonitor.com
+Updated Date: 2019-09-09T15:39:04Z
+Creation Date: 1997-09-15T04:00:00Z
+Registry Expiry Date: 2028-09-14T04:00:00Z
+Registrar: MarkMonitor Inc.
+Registrar IANA ID: 292
+Registrar Abuse Contact Email: abusecomplaints@markmonitor.com
+Registrar Abuse Contact Phone: +1.2083895740
+Domain Status: clientDeleteProhibited https://icann.org/epp#clientDeleteProhibited
+Domain Status: serverDeleteProhibited https://icann.org/epp#serverDeleteProhibited
+Name Server: NS1.GOOGLE.COM
+Name Server: NS2.GOOGLE.COM
+DNSSEC: unsigned
+>>> Last update of whois database: 2019-09-30T14:21:50Z <<<`)
+	assert.Nil(t, err)
+	assert.Zero(t, registry.Registrant)
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	registrar, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+
+	whoisInfo := Merge(registry, registrar)
+
+	// registry is authoritative for domain fields
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"clientDeleteProhibited", "serverDeleteProhibited"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.google.com", "ns2.google.com"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "1997-09-15T04:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2028-09-14T04:00:00Z")
+	assert.Equal(t, *whoisInfo.Domain.ExpirationDateInTime, *registry.Domain.ExpirationDateInTime)
+
+	// registrar fills the contacts and wins on conflicting contact values
+	assert.Equal(t, whoisInfo.Registrar.ID, "292")
+	assert.Equal(t, whoisInfo.Registrar.Name, "MarkMonitor, Inc.")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Google LLC")
+	assert.Equal(t, whoisInfo.Administrative.Country, "US")
+	assert.Equal(t, whoisInfo.Technical.Province, "CA")
+	assert.Zero(t, whoisInfo.Billing)
+	assert.Equal(t, whoisInfo.ParserVersion, Version())
+
+	// result shares nothing with the inputs
+	whoisInfo.Domain.Status[0] = "ok"
+	whoisInfo.Registrant.Organization = "Example"
+	assert.Equal(t, registry.Domain.Status[0], "clientDeleteProhibited")
+	assert.Equal(t, registrar.Registrant.Organization, "Google LLC")
+
+	// nil contacts and domain on either side
+	whoisInfo = Merge(WhoisInfo{}, WhoisInfo{Registrant: &Contact{Name: "Example"}})
+	assert.Zero(t, whoisInfo.Domain)
+	assert.Equal(t, whoisInfo.Registrant.Name, "Example")
+
+	whoisInfo = Merge(WhoisInfo{Domain: &Domain{Domain: "example.com"}}, WhoisInfo{})
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.com")
+	assert.Zero(t, whoisInfo.Registrant)
+
+	whoisInfo = Merge(WhoisInfo{Extensions: map[string]string{"a": "registry"}},
+		WhoisInfo{Extensions: map[string]string{"a": "registrar", "b": "registrar"}})
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{"a": "registry", "b": "registrar"})
+
+	// unknown dnssec status of registry does not override the registrar
+	whoisInfo = Merge(WhoisInfo{Domain: &Domain{DNSSECStatus: DNSSECStatusUnknown}},
+		WhoisInfo{Domain: &Domain{DNSSec: true, DNSSECStatus: DNSSECStatusSigned}})
+	assert.True(t, whoisInfo.Domain.DNSSec)
+	assert.Equal(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusSigned)
+
+	whoisInfo = Merge(WhoisInfo{Domain: &Domain{DNSSECStatus: DNSSECStatusUnsigned}},
+		WhoisInfo{Domain: &Domain{DNSSec: true, DNSSECStatus: DNSSECStatusSigned}})
+	assert.Equal(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusUnsigned)
+
+	whoisInfo = Merge(WhoisInfo{Domain: &Domain{DNSSECStatus: DNSSECStatusUnknown}}, WhoisInfo{Domain: &Domain{}})
+	assert.Equal(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusUnknown)
+
+	// ip and as info are copied
+	ip := &IPInfo{Networks: []*Network{{Range: "192.0.2.0 - 192.0.2.255", CIDR: []string{"192.0.2.0/24"},
+		Organization: &Contact{Name: "Example"}}}, Abuse: &Contact{Email: "abuse@example.com"}}
+	as := &ASInfo{Number: "64496", Abuse: &Contact{Email: "abuse@example.com"}}
+	whoisInfo = Merge(WhoisInfo{}, WhoisInfo{IP: ip, AS: as})
+	assert.Equal(t, whoisInfo.IP, ip)
+	assert.Equal(t, whoisInfo.AS, as)
+	whoisInfo.IP.Networks[0].CIDR[0] = "192.0.2.0/25"
+	whoisInfo.IP.Networks[0].Organization.Name = "Other"
+	whoisInfo.IP.Abuse.Email = "other@example.com"
+	whoisInfo.AS.Abuse.Email = "other@example.com"
+	assert.Equal(t, ip.Networks[0].CIDR[0], "192.0.2.0/24")
+	assert.Equal(t, ip.Networks[0].Organization.Name, "Example")
+	assert.Equal(t, ip.Abuse.Email, "abuse@example.com")
+	assert.Equal(t, as.Abuse.Email, "abuse@example.com")
+
+	ips := map[string][]string{"ns1.example.com": {"192.0.2.1"}}
+	whoisInfo = Merge(WhoisInfo{Domain: &Domain{NameServerIPs: ips}}, WhoisInfo{})
+	whoisInfo.Domain.NameServerIPs["ns1.example.com"][0] = "192.0.2.2"
+	assert.Equal(t, ips["ns1.example.com"], []string{"192.0.2.1"})
+}
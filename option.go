@@ -0,0 +1,171 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultMaxNameServers is the default max number of parsed name servers
+const defaultMaxNameServers = 1000
+
+// The languages of WithPreferLanguage
+const (
+	LanguageEnglish = "en"
+	LanguageLocal   = "local"
+)
+
+// Option is the option of domain whois parsing
+type Option func(*options)
+
+// options is the domain whois parsing options
+type options struct {
+	fastPath           bool
+	preserveCase       bool
+	sorted             bool
+	maxNameServers     int
+	suffixes           []string
+	warnings           *[]Warning
+	statusDescriptions map[string]string
+	language           string
+	expectedDomain     string
+	splitAddress       bool
+}
+
+// newOptions returns the default options with opts applied
+func newOptions(opts ...Option) options {
+	o := options{
+		fastPath:       true,
+		maxNameServers: defaultMaxNameServers,
+		language:       LanguageEnglish,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// warn appends a warning of the whois line to the warnings collector if any, i is the zero-based line index
+func (o options) warn(i int, line, message string) {
+	if o.warnings != nil {
+		*o.warnings = append(*o.warnings, Warning{Line: i + 1, Text: line, Message: message})
+	}
+}
+
+// checkDomain returns a *DomainMismatchError if the parsed domain is not the expected one, nil if no one is expected
+func (o options) checkDomain(whoisInfo WhoisInfo) error {
+	if o.expectedDomain == "" || whoisInfo.Domain == nil {
+		return nil
+	}
+
+	parsed := whoisInfo.Domain.Punycode
+	if parsed == "" {
+		parsed = strings.ToLower(whoisInfo.Domain.Domain)
+	}
+
+	if parsed != o.expectedDomain {
+		return &DomainMismatchError{Expected: o.expectedDomain, Parsed: whoisInfo.Domain.Domain}
+	}
+
+	return nil
+}
+
+// WithPreserveCase keeps the domain name and contact emails as received instead of lower case,
+// keys are always matched case-insensitively, name servers are always in lower case
+func WithPreserveCase() Option {
+	return func(o *options) {
+		o.preserveCase = true
+	}
+}
+
+// WithSorted sorts the domain name servers and status lexicographically,
+// so two snapshots of the same unchanged domain are equal, the raw order is kept by default
+func WithSorted() Option {
+	return func(o *options) {
+		o.sorted = true
+	}
+}
+
+// WithMaxNameServers sets the max number of parsed name servers, default is 1000,
+// the excess name servers are discarded with Domain.NameServersTruncated set, n less than 1 disables the cap
+func WithMaxNameServers(n int) Option {
+	return func(o *options) {
+		o.maxNameServers = n
+	}
+}
+
+// WithSuffixes sets the known suffixes to split domain name and extension at the longest matching one,
+// like example.act.edu.au into example and act.edu.au, the top-level extension is used by default
+func WithSuffixes(suffixes ...string) Option {
+	return func(o *options) {
+		for _, v := range suffixes {
+			v, _ = idna.ToASCII(strings.ToLower(strings.Trim(strings.TrimSpace(v), ".")))
+			if v != "" {
+				o.suffixes = append(o.suffixes, v)
+			}
+		}
+	}
+}
+
+// WithWarnings appends the skipped and ambiguous lines of domain whois to warnings, for finding unmapped fields,
+// the line numbers are of the prepared text for the registries which need preparation
+func WithWarnings(warnings *[]Warning) Option {
+	return func(o *options) {
+		o.warnings = warnings
+	}
+}
+
+// WithStatusDescriptions puts the descriptions in parentheses after the status codes into descriptions by code,
+// like "active" of "ok (active)", the status always holds the clean codes, descriptions must be allocated
+// by the caller, a nil map is ignored
+func WithStatusDescriptions(descriptions map[string]string) Option {
+	return func(o *options) {
+		o.statusDescriptions = descriptions
+	}
+}
+
+// WithPreferLanguage sets the language block of bilingual whois like .kr to populate the fields,
+// and the organization of .cn in both languages, LanguageEnglish or LanguageLocal, the english is used by default
+func WithPreferLanguage(language string) Option {
+	return func(o *options) {
+		o.language = language
+	}
+}
+
+// WithExpectedDomain sets the queried domain, Parse returns a *DomainMismatchError along with the parsed whois info
+// if the parsed domain is another one, like the referral whois of wrong server, the domain is compared in A-label,
+// ParseAll does not check it as the records are of different domains
+func WithExpectedDomain(domain string) Option {
+	return func(o *options) {
+		o.expectedDomain, _ = idna.ToASCII(strings.ToLower(strings.Trim(strings.TrimSpace(domain), ".")))
+	}
+}
+
+// WithSplitAddress splits the contact street of combined address like "123 St, City, Country" into
+// the street, city and country, only if the city and country are absent and neither has digits
+func WithSplitAddress() Option {
+	return func(o *options) {
+		o.splitAddress = true
+	}
+}
@@ -0,0 +1,376 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestWithPreserveCase(t *testing.T) {
+	whoisRaw := `Domain Name: Example-Shop.COM
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Registrar: Example Registrar, Inc.
+Registrar IANA ID: 376
+Registrant Organization: ACME Widgets GmbH
+Registrant Email: Hostmaster@ACME-Widgets.example
+Name Server: NS1.Example-Shop.COM`
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-shop.com")
+	assert.Equal(t, whoisInfo.Domain.Punycode, "example-shop.com")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "ACME Widgets GmbH")
+	assert.Equal(t, whoisInfo.Registrant.Email, "hostmaster@acme-widgets.example")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-shop.com"})
+
+	cased, err := Parse(whoisRaw, WithPreserveCase())
+	assert.Nil(t, err)
+	assert.Equal(t, cased.Domain.Domain, "Example-Shop.COM")
+	assert.Equal(t, cased.Domain.Punycode, "example-shop.com")
+	assert.Equal(t, cased.Domain.Name, whoisInfo.Domain.Name)
+	assert.Equal(t, cased.Domain.Extension, whoisInfo.Domain.Extension)
+	assert.Equal(t, cased.Registrant.Organization, "ACME Widgets GmbH")
+	assert.Equal(t, cased.Registrant.Email, "Hostmaster@ACME-Widgets.example")
+	assert.Equal(t, cased.Registrant.Emails, []string{"Hostmaster@ACME-Widgets.example"})
+	assert.Equal(t, cased.Domain.NameServers, []string{"ns1.example-shop.com"})
+	assert.Equal(t, cased.Registrar.ID, "376")
+}
+
+func TestWithSorted(t *testing.T) {
+	whoisRaw := `Domain Name: example.com
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Domain Status: clientUpdateProhibited https://icann.org/epp#clientUpdateProhibited
+Domain Status: clientDeleteProhibited https://icann.org/epp#clientDeleteProhibited
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Name Server: NS2.EXAMPLE.NET
+Name Server: NS1.EXAMPLE.NET
+Name Server: A.EXAMPLE.ORG`
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns2.example.net", "ns1.example.net", "a.example.org"})
+	assert.Equal(t, whoisInfo.Domain.Status,
+		[]string{"clientUpdateProhibited", "clientDeleteProhibited", "clientTransferProhibited"})
+
+	sorted, err := Parse(whoisRaw, WithSorted())
+	assert.Nil(t, err)
+	assert.Equal(t, sorted.Domain.NameServers, []string{"a.example.org", "ns1.example.net", "ns2.example.net"})
+	assert.Equal(t, sorted.Domain.Status,
+		[]string{"clientDeleteProhibited", "clientTransferProhibited", "clientUpdateProhibited"})
+
+	// same domain in another order is equal
+	other, err := Parse(`Domain Name: example.com
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Domain Status: clientDeleteProhibited https://icann.org/epp#clientDeleteProhibited
+Domain Status: clientUpdateProhibited https://icann.org/epp#clientUpdateProhibited
+Name Server: NS1.EXAMPLE.NET
+Name Server: A.EXAMPLE.ORG
+Name Server: NS2.EXAMPLE.NET`, WithSorted())
+	assert.Nil(t, err)
+	assert.Equal(t, other, sorted)
+}
+
+func TestWithMaxNameServers(t *testing.T) {
+	whoisRaw := "Domain Name: example.com\nRegistry Domain ID: 2336799_DOMAIN_COM-VRSN\n"
+	for i := 0; i < 1200; i++ {
+		whoisRaw += fmt.Sprintf("Name Server: NS%d.EXAMPLE.NET\n", i)
+	}
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfo.Domain.NameServers, 1000)
+	assert.Equal(t, whoisInfo.Domain.NameServers[999], "ns999.example.net")
+	assert.True(t, whoisInfo.Domain.NameServersTruncated)
+
+	whoisInfo, err = Parse(whoisRaw, WithMaxNameServers(2))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns0.example.net", "ns1.example.net"})
+	assert.True(t, whoisInfo.Domain.NameServersTruncated)
+
+	whoisInfo, err = Parse(whoisRaw, WithMaxNameServers(0))
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfo.Domain.NameServers, 1200)
+	assert.False(t, whoisInfo.Domain.NameServersTruncated)
+
+	whoisInfo, err = Parse("Domain Name: example.com\nName Server: NS1.EXAMPLE.NET\nName Server: NS2.EXAMPLE.NET",
+		WithMaxNameServers(2))
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfo.Domain.NameServers, 2)
+	assert.False(t, whoisInfo.Domain.NameServersTruncated)
+
+	// the name servers repeated by the referral record are not counted
+	whoisInfo, err = Parse(`Domain Name: EXAMPLE.COM
+Name Server: NS1.EXAMPLE.NET
+Name Server: NS2.EXAMPLE.NET
+>>> Last update of whois database: 2024-10-16T08:21:14Z <<<
+
+Domain Name: example.com
+Name Server: ns1.example.net
+Name Server: ns2.example.net.`, WithMaxNameServers(2))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.net", "ns2.example.net"})
+	assert.False(t, whoisInfo.Domain.NameServersTruncated)
+}
+
+func TestWithSuffixes(t *testing.T) {
+	whoisRaw := `Domain Name: example.act.edu.au
+Registry Domain ID: D407400000002123456-AU
+Last Modified: 2023-05-01T01:02:03Z
+Registrar Name: Example Registrar Pty Ltd
+Status: serverRenewProhibited https://identitydigital.au/get-au/whois-status-codes#serverRenewProhibited
+Registrant: Example School
+Name Server: ns1.example.act.edu.au`
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Name, "example.act.edu")
+	assert.Equal(t, whoisInfo.Domain.Extension, "au")
+
+	suffixes := []string{"au", "com.au", ".edu.au", "ACT.EDU.AU", "jp", "co.jp", "museum", "sea.museum"}
+	split, err := Parse(whoisRaw, WithSuffixes(suffixes...))
+	assert.Nil(t, err)
+	assert.Equal(t, split.Domain.Domain, "example.act.edu.au")
+	assert.Equal(t, split.Domain.Name, "example")
+	assert.Equal(t, split.Domain.Extension, "act.edu.au")
+
+	// registry rules still apply by top-level extension
+	assert.Equal(t, split.Registrar, whoisInfo.Registrar)
+	assert.Equal(t, split.Registrant, whoisInfo.Registrant)
+	assert.Equal(t, split.Domain.UpdatedDate, whoisInfo.Domain.UpdatedDate)
+
+	tests := map[string][]string{
+		"au_google.com.au":           {"google", "com.au"},
+		"jp_google.co.jp":            {"google", "co.jp"},
+		"jp_mod.go.jp":               {"mod.go", "jp"},
+		"museum_sea.museum":          {"sea", "museum"},
+		"tw_google.com.tw":           {"google.com", "tw"},
+		"name_john.smith.name":       {"john.smith", "name"},
+		"xn--p1ai_xn--j1ay.xn--p1ai": {"xn--j1ay", "xn--p1ai"},
+	}
+
+	for k, v := range tests {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw, WithSuffixes(suffixes...))
+		assert.Nil(t, err, k)
+		assert.Equal(t, whoisInfo.Domain.Name, v[0], k)
+		assert.Equal(t, whoisInfo.Domain.Extension, v[1], k)
+	}
+
+	name, extension := splitDomain("example.act.edu.au", []string{"edu.au", "act.edu.au"})
+	assert.Equal(t, name, "example")
+	assert.Equal(t, extension, "act.edu.au")
+
+	name, extension = splitDomain("edu.au", []string{"edu.au"})
+	assert.Equal(t, name, "edu")
+	assert.Equal(t, extension, "au")
+}
+
+func TestWithWarnings(t *testing.T) {
+	whoisRaw := `Domain Name: example.com
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Creation Date: 1995-08-14T04:00:00Z
+Registry Expiry Date: 2024-08-13T04:00:00Z
+Registrar: Example Registrar, Inc.
+Registrant Organization: Example Inc.
+Registrant Favourite Colour: blue
+Zone Flavour: vanilla
+Name Server: NS1.EXAMPLE.NET
+Creation Date: 1996-01-01T00:00:00Z`
+
+	warnings := []Warning{}
+	whoisInfo, err := Parse(whoisRaw, WithWarnings(&warnings))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "1995-08-14T04:00:00Z")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Inc.")
+	assert.Equal(t, warnings, []Warning{
+		{Line: 7, Text: "Registrant Favourite Colour: blue", Message: "unknown contact field is skipped"},
+		{Line: 8, Text: "Zone Flavour: vanilla", Message: "unknown key is skipped"},
+		{Line: 10, Text: "Creation Date: 1996-01-01T00:00:00Z", Message: "conflicting created date is ignored"},
+	})
+
+	// same result without the collector
+	expected, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo, expected)
+}
+
+func TestWithStatusDescriptions(t *testing.T) {
+	whoisRaw := `Domain Name: example.com
+Domain Status: ok (active)
+Domain Status: clientHold (locked)
+Domain Status: serverHold(suspended by registry)
+Domain Status: clientTransferProhibited (https://www.icann.org/epp#clientTransferProhibited)
+Name Server: ns1.example.com`
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ok", "clientHold", "serverHold", "clientTransferProhibited"})
+
+	descriptions := map[string]string{}
+	described, err := Parse(whoisRaw, WithStatusDescriptions(descriptions))
+	assert.Nil(t, err)
+	assert.Equal(t, described.Domain.Status, whoisInfo.Domain.Status)
+	assert.Equal(t, descriptions, map[string]string{
+		"ok":         "active",
+		"clientHold": "locked",
+		"serverHold": "suspended by registry",
+	})
+
+	described, err = Parse(whoisRaw, WithStatusDescriptions(nil))
+	assert.Nil(t, err)
+	assert.Equal(t, described.Domain.Status, whoisInfo.Domain.Status)
+}
+
+func TestWithPreferLanguage(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/kr_google.kr")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "Google Korea, LLC")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Whois Corp.(http://whois.co.kr)")
+
+	english, err := Parse(whoisRaw, WithPreferLanguage(LanguageEnglish))
+	assert.Nil(t, err)
+	assert.Equal(t, english, whoisInfo)
+
+	local, err := Parse(whoisRaw, WithPreferLanguage(LanguageLocal))
+	assert.Nil(t, err)
+	assert.Equal(t, local.Domain.Domain, "google.kr")
+	assert.Equal(t, local.Domain.NameServers, whoisInfo.Domain.NameServers)
+	assert.Equal(t, local.Domain.CreatedDateInTime, whoisInfo.Domain.CreatedDateInTime)
+	assert.Equal(t, local.Domain.ExpirationDateInTime, whoisInfo.Domain.ExpirationDateInTime)
+	assert.Equal(t, local.Domain.DNSSECStatus, DNSSECStatusUnsigned)
+	assert.Equal(t, local.Registrant.Name, "구글코리아유한회사")
+	assert.Equal(t, local.Registrant.Street, "서울시 강남구 역삼동 737 강남파이낸스센터 22층")
+	assert.Equal(t, local.Registrar.Name, "(주)후이즈(http://whois.co.kr)")
+	assert.Equal(t, local.Administrative.Email, "dns-admin@google.com")
+
+	// the romanized organization of .cn is preferred unless the local is
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/cn_example-bilingual.cn")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Beijing Example Technology Co., Ltd.")
+	assert.Equal(t, whoisInfo.Registrant.OrganizationLocal, "北京示例科技有限公司")
+
+	local, err = Parse(whoisRaw, WithPreferLanguage(LanguageLocal))
+	assert.Nil(t, err)
+	assert.Equal(t, local.Registrant.Organization, "北京示例科技有限公司")
+	assert.Zero(t, local.Registrant.OrganizationLocal)
+	assert.Equal(t, local.Registrant.Email, whoisInfo.Registrant.Email)
+
+	// the romanized only organization is kept with the local preferred
+	local, err = Parse(strings.Replace(whoisRaw, "Registrant Organization: 北京示例科技有限公司\n", "", 1),
+		WithPreferLanguage(LanguageLocal))
+	assert.Nil(t, err)
+	assert.Equal(t, local.Registrant.Organization, "Beijing Example Technology Co., Ltd.")
+
+	// the response without the local block is not affected
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	local, err = Parse(whoisRaw, WithPreferLanguage(LanguageLocal))
+	assert.Nil(t, err)
+	assert.Equal(t, local, whoisInfo)
+}
+
+func TestWithExpectedDomain(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw, WithExpectedDomain("Google.COM."))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "google.com")
+
+	// the whois of another domain is returned along with the error
+	whoisInfo, err = Parse(whoisRaw, WithExpectedDomain("example.com"))
+	assert.True(t, errors.Is(err, ErrDomainMismatch))
+	var mismatchErr *DomainMismatchError
+	assert.True(t, errors.As(err, &mismatchErr))
+	assert.Equal(t, mismatchErr.Expected, "example.com")
+	assert.Equal(t, mismatchErr.Parsed, "google.com")
+	assert.Equal(t, whoisInfo.Domain.Domain, "google.com")
+	assert.Equal(t, whoisInfo.ParserVersion, Version())
+
+	// the domain is compared in A-label
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/se_xn--fl-fka.se")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw, WithExpectedDomain("föl.se"))
+	assert.Nil(t, err)
+	_, err = Parse(whoisRaw, WithExpectedDomain("xn--fl-fka.se"))
+	assert.Nil(t, err)
+	_, err = Parse(whoisRaw, WithExpectedDomain("fol.se"))
+	assert.True(t, errors.Is(err, ErrDomainMismatch))
+
+	// the not found error is kept
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/com_likexian-have-no-money-to-register.com")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw, WithExpectedDomain("example.com"))
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestWithSplitAddress(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/rs_example-address.rs")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Street, "Knez Mihailova 12, Beograd, Serbia")
+	assert.Zero(t, whoisInfo.Registrant.City)
+	assert.Zero(t, whoisInfo.Registrant.Country)
+
+	whoisInfo, err = Parse(whoisRaw, WithSplitAddress())
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Street, "Knez Mihailova 12")
+	assert.Equal(t, whoisInfo.Registrant.City, "Beograd")
+	assert.Equal(t, whoisInfo.Registrant.Country, "Serbia")
+	assert.Equal(t, whoisInfo.Technical.Street, "Main Street 1, Suite 2")
+	assert.Equal(t, whoisInfo.Technical.City, "Dublin")
+	assert.Equal(t, whoisInfo.Technical.Country, "Ireland")
+
+	// the address with postal code is kept as is
+	assert.Equal(t, whoisInfo.Administrative.Street, "Bulevar oslobodjenja 5, 21000 Novi Sad, Serbia")
+	assert.Zero(t, whoisInfo.Administrative.City)
+
+	// the address is not split if the city or country is present
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	split, err := Parse(whoisRaw, WithSplitAddress())
+	assert.Nil(t, err)
+	assert.Equal(t, split, whoisInfo)
+}
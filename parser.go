@@ -22,6 +22,7 @@ package whoisparser
 import (
 	"errors"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/likexian/gokit/assert"
@@ -44,21 +45,154 @@ func License() string {
 	return "Licensed under the Apache License 2.0"
 }
 
-// Parse returns parsed whois info for domain, IP, or AS
-func Parse(text string) (whoisInfo WhoisInfo, err error) {
+// Parse returns parsed whois info for domain, IP, or AS, opts only apply to domain whois,
+// ErrLineTooLong is returned if a line is too long for a whois response
+func Parse(text string, opts ...Option) (whoisInfo WhoisInfo, err error) {
+	whoisInfo, err = parse(text, opts...)
+	if err == nil {
+		err = newOptions(opts...).checkDomain(whoisInfo)
+	}
+
+	return
+}
+
+// parse returns parsed whois info for domain, IP, or AS without checking the expected domain
+func parse(text string, opts ...Option) (whoisInfo WhoisInfo, err error) {
+	if text, err = normalizeWhois(text); err != nil {
+		return
+	}
+
 	if isASWhois(text) {
-		return ParseASWhois(text)
+		whoisInfo, err = ParseASWhois(text)
 	} else if isIPWhois(text) {
-		return ParseIPWhois(text)
+		whoisInfo, err = ParseIPWhois(text)
 	} else {
-		return ParseDomainWhois(text)
+		whoisInfo, err = ParseDomainWhois(text, opts...)
 	}
+
+	if err == nil {
+		whoisInfo.ParserVersion = Version()
+	}
+
+	return
 }
 
-// parseDomainWhois parses domain whois information
-func ParseDomainWhois(text string) (whoisInfo WhoisInfo, err error) { //nolint:cyclop
-	name, extension := searchDomain(text)
-	if name == "" {
+// ParseAll returns parsed whois info of every domain record in a multi-record response,
+// a single record response returns a slice of one element, the records failed to parse like not found are skipped,
+// the error of the first record is returned only if none is parsed,
+// WithExpectedDomain is not checked as the records are of different domains
+func ParseAll(text string, opts ...Option) ([]WhoisInfo, error) {
+	text, err := normalizeWhois(text)
+	if err != nil {
+		return nil, err
+	}
+
+	records := splitWhoisRecords(text)
+	if len(records) == 0 {
+		records = []string{text}
+	}
+
+	result := []WhoisInfo{}
+	var firstErr error
+	for _, v := range records {
+		whoisInfo, err := parse(v, opts...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		result = append(result, whoisInfo)
+	}
+
+	if len(result) == 0 {
+		return nil, firstErr
+	}
+
+	return result, nil
+}
+
+// splitWhoisRecords returns the domain records of whois text, records are split by the last update terminator
+// or a dashed line only if another domain name follows, or by a blank line followed by another domain name,
+// parts without domain name like disclaimers are dropped
+func splitWhoisRecords(text string) []string {
+	records := []string{}
+	record := ""
+	domain := ""
+	blank := false
+	boundary := -1
+
+	flush := func() {
+		if domain != "" {
+			records = append(records, strings.TrimSpace(record))
+		}
+		record = ""
+		domain = ""
+	}
+
+	for _, v := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(v)
+		dashed := len(line) >= 3 && strings.Trim(line, "-=") == ""
+		if dashed && boundary < 0 {
+			boundary = len(record)
+		}
+
+		name := ""
+		if vs := strings.SplitN(line, ":", 2); len(vs) == 2 && searchKeyName(vs[0]) == "domain_name" {
+			name = strings.ToLower(strings.TrimSpace(vs[1]))
+		}
+
+		// the dashed line like the section underline of .mo only splits if the next block is another domain
+		if line != "" && !dashed {
+			if name != "" && domain != "" && name != domain && (blank || boundary >= 0) {
+				if boundary >= 0 {
+					record = record[:boundary]
+				}
+				flush()
+			}
+			boundary = -1
+		}
+
+		record += v + "\n"
+		blank = line == ""
+		if domain == "" {
+			domain = name
+		}
+
+		if isWhoisFooter(line) {
+			boundary = len(record)
+		}
+	}
+
+	flush()
+
+	return records
+}
+
+// ParseDomainWhois parses domain whois information, the text is normalized the same as Parse
+func ParseDomainWhois(text string, opts ...Option) (whoisInfo WhoisInfo, err error) {
+	if text, err = normalizeWhois(text); err != nil {
+		return
+	}
+
+	return parseDomainWhois(text, newOptions(opts...))
+}
+
+// parseDomainWhois parses domain whois information, fastPath option enables the precomputed EPP key rules
+func parseDomainWhois(text string, o options) (whoisInfo WhoisInfo, err error) { //nolint:cyclop
+	// the .to whois has no domain key to search
+	if isTonicWhois(text) {
+		text = prepareTO(text)
+	}
+
+	// the error line of RIPE style servers wins over the domain named in the banner
+	if line := searchWhoisError(text); line != "" {
+		err = getWhoisErrorType(line)
+		return
+	}
+
+	name, extension := searchDomain(stripWhoisComments(text))
+	if name == "" || isNoMatchDomain(text) || isFreenomNotFoundDomain(text) {
 		err = getDomainErrorType(text)
 		return
 	}
@@ -68,20 +202,67 @@ func ParseDomainWhois(text string) (whoisInfo WhoisInfo, err error) { //nolint:c
 		return
 	}
 
+	if extension != "" && isExtInvalidDomain(text, extension) {
+		err = ErrInvalidDomain
+		return
+	}
+
 	domain := &Domain{}
 	registrar := &Contact{}
 	registrant := &Contact{}
 	administrative := &Contact{}
 	technical := &Contact{}
 	billing := &Contact{}
+	extensions := map[string]string{}
 
 	domain.Name, _ = idna.ToASCII(name)
 	domain.Extension, _ = idna.ToASCII(extension)
 
-	whoisText, _ := Prepare(text, domain.Extension)
+	// the top-level extension selects the registry rules, the known suffix only splits the name
+	tld := domain.Extension
+	if len(o.suffixes) > 0 {
+		domain.Name, domain.Extension = splitDomain(domain.Name+"."+domain.Extension, o.suffixes)
+	}
+
+	// the name of A-label is decoded for display into its own field, the name keeps the A-label
+	if strings.Contains(domain.Name, "xn--") {
+		domain.NameUnicode = unicodeDomain(domain.Name)
+	}
+
+	whoisText, prepared := prepare(text, tld, o)
+	isEPP := o.fastPath && !prepared && tld != "dk" && isEPPWhois(whoisText)
+
+	inFooter := false
+	inReferral := false
+	section := ""
+	nameServers := map[string]bool{}
 	whoisLines := strings.Split(whoisText, "\n")
 	for i := 0; i < len(whoisLines); i++ {
-		line := strings.TrimSpace(whoisLines[i])
+		line, lineNo := strings.TrimSpace(whoisLines[i]), i
+		if isWhoisFooter(line) {
+			inFooter = true
+			continue
+		}
+
+		// a referral record may follow the footer of the registry record
+		if inFooter {
+			if !strings.HasPrefix(strings.ToLower(line), "domain name:") {
+				continue
+			}
+			inFooter = false
+			inReferral = true
+		}
+
+		if line == "" {
+			section = ""
+			continue
+		}
+
+		if role := searchContactSection(line); role != "" {
+			section = role
+			continue
+		}
+
 		if len(line) < 5 || !strings.Contains(line, ":") {
 			continue
 		}
@@ -91,14 +272,16 @@ func ParseDomainWhois(text string) (whoisInfo WhoisInfo, err error) { //nolint:c
 			continue
 		}
 
+		continued := false
 		if line[len(line)-1:] == ":" {
 			i++
 			for ; i < len(whoisLines); i++ {
 				thisLine := strings.TrimSpace(whoisLines[i])
-				if strings.Contains(thisLine, ":") {
+				if strings.Contains(thisLine, ":") || isWhoisProse(thisLine) {
 					break
 				}
 				line += thisLine + ","
+				continued = continued || thisLine != ""
 			}
 			line = strings.Trim(line, ",")
 			i--
@@ -108,13 +291,34 @@ func ParseDomainWhois(text string) (whoisInfo WhoisInfo, err error) { //nolint:c
 		name := strings.TrimSpace(lines[0])
 		value := strings.TrimSpace(lines[1])
 		value = strings.TrimSpace(strings.Trim(value, ":"))
+		value = decodeMIMEWords(value)
 
 		if value == "" {
 			continue
 		}
 
-		keyName := searchKeyName(name)
-		switch keyName {
+		key, ok := whoisKey{}, false
+		if isEPP {
+			key, ok = eppKeyRule[name]
+		}
+		if !ok && section != "" {
+			key = searchWhoisKey(section+" "+name, tld)
+			ok = key.field != ""
+		}
+		if !ok {
+			key = searchWhoisKey(name, tld)
+		}
+
+		// the lines following an empty date key, like the footer of thin response, are not the date
+		if continued && assert.IsContains([]string{"created_date", "updated_date", "transferred_date",
+			"expired_date", "registrar_expired_date"}, key.rule) {
+			if _, err := parseDomainDate(value, tld); err != nil {
+				o.warn(lineNo, line, "continued value of date key is not a date")
+				continue
+			}
+		}
+
+		switch key.rule {
 		case "domain_id":
 			domain.ID = value
 		case "domain_name":
@@ -122,98 +326,243 @@ func ParseDomainWhois(text string) (whoisInfo WhoisInfo, err error) { //nolint:c
 				if firstSpace := strings.IndexByte(value, ' '); firstSpace > 0 {
 					value = value[:firstSpace]
 				}
-				domain.Domain = strings.ToLower(value)
-				domain.Punycode, _ = idna.ToASCII(domain.Domain)
+				domain.Domain = value
+				if !o.preserveCase {
+					domain.Domain = strings.ToLower(value)
+				}
+				domain.Punycode, _ = idna.ToASCII(strings.ToLower(value))
 			}
 		case "domain_status":
 			domain.Status = append(domain.Status, strings.Split(value, ",")...)
+		case "domain_remarks":
+			domain.Remarks = append(domain.Remarks, value)
+		case "domain_reseller":
+			domain.Reseller = value
+		case "domain_reseller_email":
+			domain.ResellerEmail = value
+			if !o.preserveCase {
+				domain.ResellerEmail = strings.ToLower(value)
+			}
+		case "domain_reseller_url":
+			domain.ResellerURL = value
 		case "domain_dnssec":
 			if !domain.DNSSec {
 				domain.DNSSec = isDNSSecEnabled(value)
 			}
-		case "whois_server":
+			if domain.DNSSec {
+				domain.DNSSECStatus = DNSSECStatusSigned
+			} else if isDNSSecDisabled(value) {
+				domain.DNSSECStatus = DNSSECStatusUnsigned
+			}
+		case "whois_server", "registrar_whois_server":
 			if domain.WhoisServer == "" {
 				domain.WhoisServer = value
 			}
+			if (key.rule == "registrar_whois_server" || inReferral) && domain.RegistrarWhoisServer == "" {
+				domain.RegistrarWhoisServer = value
+			}
 		case "name_servers":
-			domain.NameServers = append(domain.NameServers, strings.Split(value, ",")...)
+			// only the distinct name servers are counted, the referral record may repeat them
+			for _, v := range strings.Split(value, ",") {
+				if name := nameServerName(v); name != "" && !nameServers[name] {
+					if o.maxNameServers > 0 && len(nameServers) >= o.maxNameServers {
+						domain.NameServersTruncated = true
+						break
+					}
+					nameServers[name] = true
+				}
+				domain.NameServers = append(domain.NameServers, v)
+			}
 		case "created_date":
+			if domain.CreatedDate != "" && domain.CreatedDate != value {
+				o.warn(lineNo, line, "conflicting created date is ignored")
+			}
 			if domain.CreatedDate == "" {
 				domain.CreatedDate = value
-				if parsed, err := parseDateString(value); err == nil {
+				if parsed, err := parseDomainDate(value, tld); err == nil {
 					domain.CreatedDateInTime = &parsed
 				}
 			}
 		case "updated_date":
-			if domain.UpdatedDate == "" {
+			// the registry updated date is preferred, the one of referral record is the registrar updated date
+			if inReferral {
+				if domain.RegistrarUpdatedDate == "" {
+					domain.RegistrarUpdatedDate = value
+					if parsed, err := parseDomainDate(value, tld); err == nil {
+						domain.RegistrarUpdatedDateInTime = &parsed
+					}
+				}
+			} else if domain.UpdatedDate == "" {
 				domain.UpdatedDate = value
-				if parsed, err := parseDateString(value); err == nil {
+				if parsed, err := parseDomainDate(value, tld); err == nil {
 					domain.UpdatedDateInTime = &parsed
 				}
 			}
+		case "transferred_date":
+			if domain.TransferredDate == "" {
+				domain.TransferredDate = value
+				if parsed, err := parseDomainDate(value, tld); err == nil {
+					domain.TransferredDateInTime = &parsed
+				}
+			}
 		case "expired_date":
+			if domain.ExpirationDate != "" && domain.ExpirationDate != value {
+				o.warn(lineNo, line, "conflicting expiration date is ignored")
+			}
 			if domain.ExpirationDate == "" {
 				domain.ExpirationDate = value
-				if parsed, err := parseDateString(value); err == nil {
+				if parsed, err := parseDomainDate(value, tld); err == nil {
 					domain.ExpirationDateInTime = &parsed
 				}
 			}
+		case "registrar_expired_date":
+			// the registrar expiration date is kept apart, it may differ from the registry one
+			if domain.RegistrarExpirationDate == "" {
+				domain.RegistrarExpirationDate = value
+				if parsed, err := parseDomainDate(value, tld); err == nil {
+					domain.RegistrarExpirationDateInTime = &parsed
+				}
+			}
 		case "referral_url":
 			registrar.ReferralURL = value
 		default:
-			name = clearKeyName(name)
-			if !strings.Contains(name, " ") {
-				if name == "registrar" {
-					name += " name"
-				} else if domain.Extension == "dk" {
-					name = "registrant " + name
+			// registry specific data that has no standard field
+			if name := strings.TrimPrefix(key.rule, "extension_"); name != key.rule {
+				if extensions[name] == "" {
+					extensions[name] = value
 				} else {
-					name += " organization"
+					extensions[name] += ", " + value
+				}
+				continue
+			}
+
+			var contact *Contact
+			switch key.contact {
+			case "registrar", "registration":
+				contact = registrar
+			case "registrant", "holder":
+				contact = registrant
+			case "admin", "administrative":
+				contact = administrative
+			case "tech", "technical":
+				contact = technical
+			case "bill", "billing":
+				contact = billing
+			default:
+				o.warn(lineNo, line, "unknown key is skipped")
+				continue
+			}
+
+			// the phone and email may be packed into the name line, like "Name / Phone / Email",
+			// a line with the email only is kept as it is
+			if key.field == "registrant_name" || key.field == "registrant_organization" {
+				if name, phone, email := splitContactLine(value); name != "" && (phone != "" || email != "") {
+					if phone != "" {
+						parseContact(contact, "registrant_phone", phone)
+					}
+					if email != "" {
+						if !o.preserveCase {
+							email = strings.ToLower(email)
+						}
+						parseContact(contact, "registrant_email", email)
+					}
+					value = name
 				}
 			}
-			ns := strings.SplitN(name, " ", 2)
-			name = strings.TrimSpace("registrant " + ns[1])
-			if ns[0] == "registrar" || ns[0] == "registration" {
-				parseContact(registrar, name, value)
-			} else if ns[0] == "registrant" || ns[0] == "holder" {
-				parseContact(registrant, name, value)
-			} else if ns[0] == "admin" || ns[0] == "administrative" {
-				parseContact(administrative, name, value)
-			} else if ns[0] == "tech" || ns[0] == "technical" {
-				parseContact(technical, name, value)
-			} else if ns[0] == "bill" || ns[0] == "billing" {
-				parseContact(billing, name, value)
+
+			if key.field == "registrant_email" && !o.preserveCase {
+				value = strings.ToLower(value)
+			}
+
+			if value != "" {
+				parseContact(contact, key.field, value)
+			}
+
+			if key.field == "" {
+				o.warn(lineNo, line, "unknown contact field is skipped")
 			}
 		}
 	}
 
-	domain.NameServers = fixNameServers(domain.NameServers)
+	domain.NameServers, domain.NameServerIPs = fixNameServers(domain.NameServers)
+	if o.statusDescriptions != nil {
+		for _, v := range domain.Status {
+			if code, description := statusDescription(v); description != "" {
+				o.statusDescriptions[code] = description
+			}
+		}
+	}
 	domain.Status = fixDomainStatus(domain.Status)
 
 	domain.NameServers = xslice.Unique(domain.NameServers).([]string)
 	domain.Status = xslice.Unique(domain.Status).([]string)
 
+	if domain.UpdatedDate == "" {
+		domain.UpdatedDate, domain.UpdatedDateInTime = domain.RegistrarUpdatedDate, domain.RegistrarUpdatedDateInTime
+	}
+
+	if domain.ExpirationDate == "" {
+		domain.ExpirationDate, domain.ExpirationDateInTime = domain.RegistrarExpirationDate,
+			domain.RegistrarExpirationDateInTime
+	}
+
+	if o.sorted {
+		sort.Strings(domain.NameServers)
+		sort.Strings(domain.Status)
+	}
+
+	// omitted by JSON, keep them nil to unmarshal into the same whois info
+	if len(domain.NameServers) == 0 {
+		domain.NameServers = nil
+	}
+
+	if len(domain.Status) == 0 {
+		domain.Status = nil
+	}
+
+	if len(domain.Remarks) == 0 {
+		domain.Remarks = nil
+	}
+
+	if domain.DNSSECStatus == "" {
+		domain.DNSSECStatus = DNSSECStatusUnknown
+	}
+
+	for _, v := range []*Contact{registrar, registrant, administrative, technical, billing} {
+		if o.splitAddress && v.City == "" && v.Country == "" {
+			if street, city, country := splitAddress(v.Street); city != "" {
+				v.Street, v.City, v.Country = street, city, country
+			}
+		}
+		v.CountryCode = countryCode(v.Country)
+		v.PhoneE164 = phoneE164(v.Phone, v.Country)
+	}
+
 	whoisInfo.Domain = domain
-	if *registrar != (Contact{}) {
+	if !isZeroContact(registrar) {
 		whoisInfo.Registrar = registrar
 	}
 
-	if *registrant != (Contact{}) {
+	if !isZeroContact(registrant) {
 		whoisInfo.Registrant = registrant
 	}
 
-	if *administrative != (Contact{}) {
+	if !isZeroContact(administrative) {
 		whoisInfo.Administrative = administrative
 	}
 
-	if *technical != (Contact{}) {
+	if !isZeroContact(technical) {
 		whoisInfo.Technical = technical
 	}
 
-	if *billing != (Contact{}) {
+	if !isZeroContact(billing) {
 		whoisInfo.Billing = billing
 	}
 
+	if len(extensions) > 0 {
+		whoisInfo.Extensions = extensions
+	}
+
 	return
 }
 
@@ -759,23 +1108,87 @@ func isIPWhois(text string) bool {
 	return false
 }
 
+// isWhoisFooter checks if the line is the last update terminator, after which only notices follow
+func isWhoisFooter(line string) bool {
+	return strings.HasPrefix(line, ">>>") && strings.Contains(strings.ToLower(line), "last update of")
+}
+
+// isWhoisComment checks if the line is the "%" comment of RIPE style servers, like the query service banner
+func isWhoisComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "%")
+}
+
+// stripWhoisComments returns whois text without the "%" comment lines
+func stripWhoisComments(text string) string {
+	lines := []string{}
+	for _, v := range strings.Split(text, "\n") {
+		if !isWhoisComment(v) {
+			lines = append(lines, v)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isWhoisProse checks if the line is free text of sentences, like the legal notices of registrars,
+// which is never the continuation of a field value
+func isWhoisProse(line string) bool {
+	if len(strings.Fields(line)) < 10 {
+		return false
+	}
+
+	return strings.HasSuffix(line, ".") || strings.Contains(line, ". ")
+}
+
+// isEPPWhois checks if the WHOIS text is in the ICANN gTLD EPP format
+func isEPPWhois(text string) bool {
+	return strings.Contains(text, "Registry Domain ID:") && strings.Contains(text, "Registrar IANA ID:")
+}
+
+// isTonicWhois returns if text is the minimal .to whois response, which is led by the Tonic whoisd banner,
+// or the bare domain name only
+func isTonicWhois(text string) bool {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "Tonic whoisd") {
+		return true
+	}
+
+	return !strings.ContainsAny(text, " \n:") && strings.HasSuffix(strings.ToLower(text), ".to")
+}
+
 // isASWhois checks if the WHOIS text is for an AS number
 func isASWhois(text string) bool {
 	return strings.Contains(text, "ASNumber:") || strings.Contains(text, "ASName:") || strings.Contains(text, "aut-num:")
 }
 
-// parseContact do parse contact info
-func parseContact(contact *Contact, name, value string) {
-	switch searchKeyName(name) {
+// parseContact do parse contact info by the field rule
+func parseContact(contact *Contact, field, value string) {
+	switch field {
 	case "registrant_id":
 		contact.ID = value
+	case "registrant_iana_id":
+		// the "Sponsoring Registrar IANA ID" and "Registrar IANA ID" may both be given, the first one is kept
+		if contact.ID == "" {
+			contact.ID = value
+		}
 	case "registrant_name":
 		if contact.Name == "" {
 			contact.Name = value
 		}
 	case "registrant_organization":
+		// the bilingual organization is kept both, the romanized one is preferred
 		if contact.Organization == "" {
 			contact.Organization = value
+		} else if contact.OrganizationLocal == "" && isNonLatin(value) != isNonLatin(contact.Organization) {
+			if isNonLatin(value) {
+				contact.OrganizationLocal = value
+			} else {
+				contact.OrganizationLocal, contact.Organization = contact.Organization, value
+			}
+		}
+	case "registrant_organization_local":
+		if contact.OrganizationLocal == "" {
+			contact.OrganizationLocal = value
 		}
 	case "registrant_street":
 		if contact.Street == "" {
@@ -793,6 +1206,10 @@ func parseContact(contact *Contact, name, value string) {
 		contact.Country = value
 	case "registrant_phone":
 		contact.Phone = value
+	case "registrant_abuse_phone":
+		if contact.Phone == "" {
+			contact.Phone = value
+		}
 	case "registrant_phone_ext":
 		contact.PhoneExt = value
 	case "registrant_fax":
@@ -800,15 +1217,48 @@ func parseContact(contact *Contact, name, value string) {
 	case "registrant_fax_ext":
 		contact.FaxExt = value
 	case "registrant_email":
-		contact.Email = strings.ToLower(value)
+		// the value that is not an email address, like "redacted for privacy", is kept as email only
+		emails := splitEmails(value)
+		for _, v := range emails {
+			if !assert.IsContains(contact.Emails, v) {
+				contact.Emails = append(contact.Emails, v)
+			}
+		}
+		if contact.Email == "" {
+			contact.Email = value
+			if len(emails) > 0 {
+				contact.Email = emails[0]
+			}
+		}
+	case "registrant_anonymous":
+		if strings.EqualFold(value, "yes") {
+			contact.Redacted = true
+		}
 	}
 }
 
-var searchDomainRx1 = regexp.MustCompile(`(?i)\[?domain\:?(\s*\_?name)?\]?[\s\.]*\:?` +
+var searchDomainRx1 = regexp.MustCompile(`(?i)\[?(?:domain|도메인)\:?(\s*\_?name|이름)?\]?[\s\.]*\:?` +
 	`\s*([^\s\,\;\@\(\)]+)\.([^\s\,\;\(\)\.]{2,})`)
-var searchDomainRx2 = regexp.MustCompile(`(?i)\[?domain\:?(\s*\_?name)?\]?[\s\.]*\:?` +
+var searchDomainRx2 = regexp.MustCompile(`(?i)\[?(?:domain|도메인)\:?(\s*\_?name|이름)?\]?[\s\.]*\:?` +
 	`\s*([^\s\,\;\@\(\)\.]{2,})\n`)
 
+// splitDomain splits domain into name and extension at the longest known suffix,
+// the last label is the extension if no suffix matches, like example.act.edu.au into example and act.edu.au
+func splitDomain(domain string, suffixes []string) (name, extension string) {
+	pos := strings.LastIndex(domain, ".")
+	for _, v := range suffixes {
+		if strings.HasSuffix(domain, "."+v) && len(domain)-len(v)-1 < pos {
+			pos = len(domain) - len(v) - 1
+		}
+	}
+
+	if pos < 1 {
+		return domain, ""
+	}
+
+	return domain[:pos], domain[pos+1:]
+}
+
 // searchDomain finds domain name and extension from whois information
 func searchDomain(text string) (name, extension string) {
 	m := searchDomainRx1.FindStringSubmatch(text)
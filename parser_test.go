@@ -20,10 +20,12 @@
 package whoisparser
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/likexian/gokit/assert"
 	"github.com/likexian/gokit/xfile"
@@ -58,6 +60,72 @@ func TestVersion(t *testing.T) {
 	assert.Contains(t, License(), "Apache License")
 }
 
+func TestParseParserVersion(t *testing.T) {
+	whoisInfo, err := Parse(`Domain Name: example.com
+Registry Domain ID: 2336799_DOMAIN_COM-VRSN
+Registrar IANA ID: 376`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.ParserVersion, Version())
+
+	data, err := json.Marshal(whoisInfo)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"parser_version":"`+Version()+`"`)
+
+	whoisInfo, err = ParseDomainWhois(`Domain Name: example.com`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.ParserVersion, "")
+
+	data, err = json.Marshal(whoisInfo)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "parser_version")
+
+	whoisInfo, err = Parse("")
+	assert.NotNil(t, err)
+	assert.Equal(t, whoisInfo.ParserVersion, "")
+}
+
+func TestParseJSONRoundTrip(t *testing.T) {
+	dirs, err := xfile.ListDir(noterrorDir, xfile.TypeFile, -1)
+	assert.Nil(t, err)
+
+	for _, v := range dirs {
+		if v.Name == "README.md" || strings.HasSuffix(v.Name, ".json") || strings.HasSuffix(v.Name, ".pre") {
+			continue
+		}
+
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v.Name)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, v.Name)
+
+		data, err := json.Marshal(whoisInfo)
+		assert.Nil(t, err, v.Name)
+
+		var result WhoisInfo
+		err = json.Unmarshal(data, &result)
+		assert.Nil(t, err, v.Name)
+		assert.Equal(t, result, whoisInfo, v.Name)
+	}
+
+	// named time zone is encoded as the offset
+	whoisInfo, err := Parse(`Domain Name: example.com
+Creation Date: 2001-02-03 04:05:06 CST
+Registry Expiry Date: 2031-02-03 04:05:06 UTC`)
+	assert.Nil(t, err)
+	assert.Zero(t, whoisInfo.Domain.Status)
+	assert.Zero(t, whoisInfo.Domain.NameServers)
+
+	data, err := json.Marshal(whoisInfo)
+	assert.Nil(t, err)
+
+	var result WhoisInfo
+	err = json.Unmarshal(data, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, result, whoisInfo)
+	assert.Equal(t, result.Domain.CreatedDate, "2001-02-03 04:05:06 CST")
+}
+
 func TestParseError(t *testing.T) {
 	tests := map[error]string{
 		ErrNotFoundDomain:    "No matching record.",
@@ -78,6 +146,37 @@ func TestParseError(t *testing.T) {
 	assert.Equal(t, err, ErrNotFoundDomain)
 }
 
+func TestParseWhoisComments(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/mk_example-commented.mk")
+	assert.Nil(t, err)
+
+	// the domain named in the comment banner is skipped
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-commented.mk")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Ana Angelovska")
+	assert.Equal(t, whoisInfo.Technical.Name, "Example Commented NOC")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example-commented.mk")
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/mk_likexian-have-no-money-to-register.mk")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+
+	tests := map[string]error{
+		"%ERROR:101: no entries found":                           ErrNotFoundDomain,
+		"% ERROR:101:":                                           ErrNotFoundDomain,
+		"%ERROR:201: access denied for 192.0.2.1":                ErrDomainDataInvalid,
+		"%ERROR:202: access control limit reached for 192.0.2.1": ErrDomainLimitExceed,
+	}
+
+	for k, v := range tests {
+		_, err = Parse("% This is the RIPE Database query service.\n% domain: ripe.net\n\n" + k + "\n")
+		assert.Equal(t, err, v, k)
+	}
+}
+
 func TestParse(t *testing.T) {
 	extensions := []string{}
 	domains := map[string][]string{}
@@ -111,29 +210,32 @@ func TestParse(t *testing.T) {
 
 		if !assert.IsContains([]string{"", "at", "aq", "br", "ch", "de", "edu", "eu", "fr", "gov", "hk",
 			"hm", "int", "it", "jp", "kr", "kz", "mo", "nl", "nz", "pl", "pm", "re", "ro", "ru", "su", "tf", "ee",
-			"tk", "travel", "tv", "tw", "uk", "wf", "yt", "ir", "fi", "rs", "dk", "by", "ua",
-			"xn--mgba3a4f16a", "xn--p1ai", "se", "nu", "hu"}, extension) {
+			"tk", "to", "ml", "ga", "travel", "tv", "tw", "uk", "wf", "yt", "ir", "fi", "rs", "dk", "by", "ua",
+			"xn--mgba3a4f16a", "xn--p1ai", "se", "nu", "hu", "ax", "fo", "ad", "mc", "sm", "mk"}, extension) {
 			assert.NotZero(t, whoisInfo.Domain.ID)
 		}
 
-		if !assert.IsContains([]string{"at", "ch", "edu", "eu", "int", "kr", "mo", "tw", "ir", "pl", "tk", "by",
+		if !assert.IsContains([]string{"at", "ch", "edu", "eu", "int", "kr", "mo", "tw", "ir", "pl", "tk", "to", "ga", "by",
 			"xn--mgba3a4f16a", "hu"}, extension) {
 			assert.NotZero(t, whoisInfo.Domain.Status)
 		}
 
 		if assert.IsContains([]string{"aftermarket.pl", "nazwa.pl", "git.nl", "git.wf", "by",
-			"switch.ch", "git.xyz", "emilstahl.dk", "folketinget.dk", "nic.nu", "xn--fl-fka.se"}, domain) {
+			"switch.ch", "git.xyz", "emilstahl.dk", "folketinget.dk", "nic.nu", "xn--fl-fka.se", "example-signed.ee",
+			"example-signed.jp"}, domain) {
 			assert.True(t, whoisInfo.Domain.DNSSec)
+			assert.Equal(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusSigned)
 		} else {
 			assert.False(t, whoisInfo.Domain.DNSSec)
+			assert.NotEqual(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusSigned)
 		}
 
 		if !assert.IsContains([]string{"aero", "ai", "at", "aq", "asia", "berlin", "biz", "br", "ch", "cn",
 			"co", "cymru", "de", "edu", "eu", "fr", "gov", "hk", "hm", "in", "int", "it", "jp", "kr",
-			"la", "london", "me", "mo", "museum", "name", "nl", "nz", "pm", "re", "ro", "ru", "sh",
-			"kz", "su", "tel", "ee", "tf", "tk", "travel", "tw", "uk", "us", "wales", "wf", "xxx",
+			"la", "london", "me", "mo", "museum", "name", "nl", "nz", "ph", "pm", "re", "ro", "ru", "sh",
+			"kz", "su", "tel", "ee", "tf", "tk", "to", "ml", "ga", "travel", "tw", "uk", "us", "wales", "wf", "xxx",
 			"yt", "ir", "fi", "rs", "dk", "by", "ua", "xn--mgba3a4f16a", "xn--fiqs8s", "xn--p1ai",
-			"se", "nu", "hu"}, extension) {
+			"se", "nu", "hu", "ax", "fo", "ad", "mc", "sm", "mk"}, extension) {
 			assert.NotZero(t, whoisInfo.Domain.WhoisServer)
 		}
 
@@ -141,7 +243,7 @@ func TestParse(t *testing.T) {
 			assert.NotZero(t, whoisInfo.Domain.NameServers)
 		}
 
-		if !assert.IsContains([]string{"aq", "ai", "at", "au", "de", "eu", "gov", "hm", "name", "nl", "nz", "ir", "tk",
+		if !assert.IsContains([]string{"aq", "ai", "at", "au", "de", "eu", "gov", "hm", "name", "nl", "nz", "ir", "tk", "to",
 			"xn--mgba3a4f16a"}, extension) &&
 			!strings.Contains(domain, "ac.jp") &&
 			!strings.Contains(domain, "co.jp") &&
@@ -152,13 +254,14 @@ func TestParse(t *testing.T) {
 		}
 
 		if !assert.IsContains([]string{"aq", "ai", "at", "ch", "cn", "eu", "gov", "hk", "hm", "mo",
-			"name", "nl", "ro", "ru", "su", "tk", "tw", "dk", "xn--fiqs8s", "xn--p1ai", "hu"}, extension) {
+			"name", "nl", "ro", "ru", "su", "tk", "to", "ml", "ga", "tw", "dk", "xn--fiqs8s", "xn--p1ai", "hu",
+			"ad", "mc"}, extension) {
 			assert.NotZero(t, whoisInfo.Domain.UpdatedDate)
 			assert.NotNil(t, whoisInfo.Domain.UpdatedDateInTime)
 		}
 
 		if !assert.IsContains([]string{"", "ai", "at", "aq", "au", "br", "ch", "de", "eu", "gov", "ee",
-			"hm", "int", "name", "nl", "nz", "tk", "kz", "hu"}, extension) &&
+			"hm", "int", "name", "nl", "nz", "tk", "to", "kz", "hu", "sm"}, extension) &&
 			!strings.Contains(domain, "ac.jp") &&
 			!strings.Contains(domain, "co.jp") &&
 			!strings.Contains(domain, "go.jp") &&
@@ -169,23 +272,29 @@ func TestParse(t *testing.T) {
 
 		if !assert.IsContains([]string{"", "ai", "at", "aq", "au", "br", "ca", "ch", "cn", "cx", "de",
 			"edu", "eu", "fr", "gov", "gs", "hk", "hm", "int", "it", "jp", "kr", "kz", "la", "mo", "nl",
-			"nz", "pl", "pm", "re", "ro", "ru", "su", "tf", "tk", "tw", "uk", "wf", "yt", "ir", "fi", "rs",
-			"ee", "dk", "by", "ua", "xn--mgba3a4f16a", "xn--fiqs8s", "xn--p1ai", "se", "nu", "hu"}, extension) {
+			"nz", "ph", "pl", "pm", "re", "ro", "ru", "su", "tf", "tk", "to", "ml", "ga", "tw", "uk", "wf", "yt",
+			"ir", "fi", "rs", "ee", "dk", "by", "ua", "xn--mgba3a4f16a", "xn--fiqs8s", "xn--p1ai", "se", "nu",
+			"hu", "ax", "fo", "gl", "ad", "mc", "sm", "mk"}, extension) {
 			assert.NotZero(t, whoisInfo.Registrar.ID)
 		}
 
 		if !assert.IsContains([]string{"", "at", "aq", "br", "de",
-			"edu", "gov", "hm", "int", "jp", "mo", "tk", "ir", "dk", "xn--mgba3a4f16a", "hu"}, extension) {
+			"edu", "gov", "hm", "int", "jp", "mo", "tk", "to", "ml", "ga", "ir", "dk", "xn--mgba3a4f16a", "hu",
+			"ad", "mc", "sm"}, extension) {
 			assert.NotZero(t, whoisInfo.Registrar.Name)
 		}
 
 		if !assert.IsContains([]string{"", "aero", "ai", "at", "aq", "asia", "au", "br", "ch", "cn", "de",
 			"edu", "gov", "hk", "hm", "int", "jp", "kr", "kz", "la", "london", "love", "mo",
-			"museum", "name", "nl", "nz", "pl", "ru", "su", "tk", "top", "ir", "fi", "rs", "dk", "by", "ua",
-			"xn--mgba3a4f16a", "xn--fiqs8s", "xn--p1ai", "se", "nu", "hu"}, extension) {
+			"museum", "name", "nl", "nz", "pl", "ru", "su", "tk", "to", "ml", "ga", "top", "ir", "fi", "rs", "dk", "by", "ua",
+			"xn--mgba3a4f16a", "xn--fiqs8s", "xn--p1ai", "se", "nu", "hu", "fo", "ad", "mc", "sm", "mk"}, extension) {
 			assert.NotZero(t, whoisInfo.Registrar.ReferralURL)
 		}
 
+		// keep the golden files stable across releases
+		assert.Equal(t, whoisInfo.ParserVersion, Version())
+		whoisInfo.ParserVersion = ""
+
 		err = xjson.Dump(noterrorDir+"/"+v.Name+".json", whoisInfo)
 		assert.Nil(t, err)
 
@@ -245,3 +354,1706 @@ func TestAssearchDomain(t *testing.T) {
 		assert.Equal(t, extension, v.extension)
 	}
 }
+
+func TestParseRegistrarAddress(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-registrar.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar, LLC")
+	assert.Equal(t, whoisInfo.Registrar.Street, "100 Main Street, Suite 200")
+	assert.Equal(t, whoisInfo.Registrar.City, "Springfield")
+	assert.Equal(t, whoisInfo.Registrar.Province, "IL")
+	assert.Equal(t, whoisInfo.Registrar.PostalCode, "62701")
+	assert.Equal(t, whoisInfo.Registrar.Country, "US")
+	assert.Equal(t, whoisInfo.Registrar.Phone, "+1.2175550100")
+	assert.Equal(t, whoisInfo.Registrar.Email, "support@example-registrar.com")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Holdings Inc.")
+	assert.Equal(t, whoisInfo.Registrant.Province, "CA")
+
+	// abuse phone is used only without registrar phone
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.Phone, "+1.2083895740")
+}
+
+func TestParseReferralURL(t *testing.T) {
+	tests := map[string]string{
+		"com_google.com": "http://www.markmonitor.com",
+		"uk_google.uk":   "http://www.markmonitor.com",
+		"uk_git.uk":      "http://www.123-reg.co.uk",
+		"ee_telia.ee":    "http://www.telia.ee",
+		"eu_google.eu":   "https://www.markmonitor.com/",
+		"fi_google.fi":   "www.markmonitor.com",
+		"fr_google.fr":   "http://www.markmonitor.com",
+		"ua_google.ua":   "http://markmonitor.com",
+	}
+
+	for k, v := range tests {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, k)
+		assert.Equal(t, whoisInfo.Registrar.ReferralURL, v, k)
+	}
+
+	// registrar url aliases of gTLD response
+	for _, v := range []string{"Registrar URL", "Referral URL", "Registrar Website", "Registration Service URL"} {
+		whoisInfo, err := Parse("Domain Name: example.com\nRegistrar: Example Registrar, Inc.\n" +
+			v + ": http://www.example-registrar.com")
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.Registrar.ReferralURL, "http://www.example-registrar.com", v)
+	}
+}
+
+func TestParseReseller(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/net_example-reseller.net")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Reseller, "Example Hosting Ltd")
+	assert.Equal(t, whoisInfo.Domain.ResellerEmail, "support@example-hosting.net")
+	assert.Equal(t, whoisInfo.Domain.ResellerURL, "https://www.example-hosting.net")
+	assert.Equal(t, whoisInfo.Registrar.Name, "GANDI SAS")
+	assert.Equal(t, whoisInfo.Registrar.ReferralURL, "http://www.gandi.net")
+
+	whoisInfo, err = Parse(whoisRaw, WithPreserveCase())
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ResellerEmail, "Support@Example-Hosting.net")
+
+	// the reseller block of .nl is keyed by the reseller name
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/nl_git.nl")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Reseller, "Yourhosting")
+	assert.Zero(t, whoisInfo.Domain.ResellerEmail)
+}
+
+func TestParseCaseInsensitiveKeys(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/info_example-caps.info")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D503300001234567-LRMS")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2025-09-30T12:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-registrar.com", "ns2.example-registrar.com"})
+	assert.Equal(t, whoisInfo.Registrar.ID, "9999")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar, Inc.")
+	assert.Equal(t, whoisInfo.Registrar.ReferralURL, "http://www.example-registrar.com")
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXC-R1")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Caps Ltd")
+	assert.Equal(t, whoisInfo.Administrative.ID, "EXC-A1")
+	assert.Equal(t, whoisInfo.Technical.ID, "EXC-T1")
+
+	// the key and contact section header in any case
+	for _, v := range []string{"Registrar URL", "registrar url", "REGISTRAR URL"} {
+		whoisInfo, err = Parse("Domain Name: example.com\n" + v + ": http://www.example-registrar.com\n\n" +
+			strings.ToUpper("Technical Contact") + ":\nName: Hostmaster")
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.Registrar.ReferralURL, "http://www.example-registrar.com", v)
+		assert.Equal(t, whoisInfo.Technical.Name, "Hostmaster", v)
+	}
+}
+
+func TestParseUpdatedDate(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-updated.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2023-03-14T09:26:53Z")
+	assert.Equal(t, whoisInfo.Domain.UpdatedDateInTime.Format(time.RFC3339), "2023-03-14T09:26:53Z")
+	assert.Equal(t, whoisInfo.Domain.RegistrarUpdatedDate, "2023-05-20T12:00:41Z")
+	assert.Equal(t, whoisInfo.Domain.RegistrarUpdatedDateInTime.Format(time.RFC3339), "2023-05-20T12:00:41Z")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Updated LLC")
+
+	// registrar updated date is used without the registry one
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "Updated Date: 2023-03-14T09:26:53Z", "Updated Date:", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2023-05-20T12:00:41Z")
+	assert.Equal(t, whoisInfo.Domain.RegistrarUpdatedDate, "2023-05-20T12:00:41Z")
+
+	// no referral record
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.NotZero(t, whoisInfo.Domain.UpdatedDate)
+	assert.Zero(t, whoisInfo.Domain.RegistrarUpdatedDate)
+}
+
+func TestParseTransferredDate(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/org_example-transferred.org")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.TransferredDate, "2022-08-15T10:11:12Z")
+	assert.Equal(t, whoisInfo.Domain.TransferredDateInTime.Format(time.RFC3339), "2022-08-15T10:11:12Z")
+
+	// the ccTLD phrasing of .se and .nu
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/se_google.se")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.TransferredDate, "2009-03-06")
+	assert.Equal(t, whoisInfo.Domain.TransferredDateInTime.Format(time.RFC3339), "2009-03-06T00:00:00Z")
+
+	for _, v := range []string{"Last Transfer Date", "Transfer Date", "Registrar Transfer Date"} {
+		whoisInfo, err = Parse("Domain Name: example.com\n" + v + ": 2021-01-02T03:04:05Z")
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.Domain.TransferredDate, "2021-01-02T03:04:05Z", v)
+	}
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Zero(t, whoisInfo.Domain.TransferredDate)
+	assert.Zero(t, whoisInfo.Domain.TransferredDateInTime)
+}
+
+func TestParseRegistrarExpirationDate(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-registrar-expiry.com")
+	assert.Nil(t, err)
+
+	// the registry expiry date is not overridden by the registrar one ahead of it
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2026-05-28T14:20:05Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2026-05-28T14:20:05Z")
+	assert.Equal(t, whoisInfo.Domain.RegistrarExpirationDate, "2025-05-28T14:20:05Z")
+	assert.Equal(t, whoisInfo.Domain.RegistrarExpirationDateInTime.Format(time.RFC3339), "2025-05-28T14:20:05Z")
+
+	// the registrar expiration date is the expiration date if the registry one is missing
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "Registry Expiry Date: 2026-05-28T14:20:05Z\n", "", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2025-05-28T14:20:05Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime, whoisInfo.Domain.RegistrarExpirationDateInTime)
+
+	// the registrar one is not set by the registry expiry date
+	whoisInfo, err = Parse(strings.Replace(whoisRaw,
+		"Registrar Registration Expiration Date: 2025-05-28T14:20:05Z\n", "", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2026-05-28T14:20:05Z")
+	assert.Zero(t, whoisInfo.Domain.RegistrarExpirationDate)
+	assert.Zero(t, whoisInfo.Domain.RegistrarExpirationDateInTime)
+
+	// the raw date is kept if it is not parsable
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "2025-05-28T14:20:05Z", "next spring", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.RegistrarExpirationDate, "next spring")
+	assert.Zero(t, whoisInfo.Domain.RegistrarExpirationDateInTime)
+}
+
+func TestParseFax(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/net_example-fax.net")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Phone, "+1.2175550100")
+	assert.Equal(t, whoisInfo.Registrant.PhoneExt, "12")
+	assert.Equal(t, whoisInfo.Registrant.Fax, "+1.2175550199")
+	assert.Equal(t, whoisInfo.Registrant.FaxExt, "34")
+	assert.Equal(t, whoisInfo.Administrative.Phone, "+1.2175550200")
+	assert.Equal(t, whoisInfo.Administrative.PhoneExt, "56")
+	assert.Equal(t, whoisInfo.Administrative.Fax, "+1.2175550299")
+	assert.Equal(t, whoisInfo.Administrative.FaxExt, "78")
+	assert.Equal(t, whoisInfo.Technical.Phone, "+1.2175550300")
+	assert.Equal(t, whoisInfo.Technical.Fax, "+1.2175550399")
+
+	// the labeled phone and fax lines of .pl registrar block
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/pl_aftermarket.pl")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.Phone, "+357.22761649")
+	assert.Equal(t, whoisInfo.Registrar.Fax, "+357.22767543")
+	assert.Equal(t, whoisInfo.Registrar.ReferralURL, "http://www.AfterMarket.pl/contact.php")
+}
+
+func TestRegisterContactSection(t *testing.T) {
+	whoisRaw := `Domain Name: example.com
+Creation Date: 2001-02-03T04:05:06Z
+
+Technical Contact Information:
+Name: Network Operations
+Email: noc@example.net
+
+Domain Holder Details:
+Name: Example Owner
+Organisation: Example Inc.
+Email: owner@example.com
+Phone: +1.5551234567
+
+Name Server: ns1.example.com`
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Technical.Name, "Network Operations")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example.net")
+	assert.Zero(t, whoisInfo.Registrant)
+
+	err = RegisterContactSection("Domain Holder Details:", "Registrant")
+	assert.Nil(t, err)
+	defer func() {
+		contactSectionsMu.Lock()
+		delete(contactSections, "domain holder details")
+		contactSectionsMu.Unlock()
+	}()
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "Example Owner")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Inc.")
+	assert.Equal(t, whoisInfo.Registrant.Email, "owner@example.com")
+	assert.Equal(t, whoisInfo.Registrant.Phone, "+1.5551234567")
+	assert.Equal(t, whoisInfo.Technical.Name, "Network Operations")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.com"})
+
+	// every role of ContactRoles and the short names are accepted
+	for _, v := range append([]string{"admin", "tech", "bill"}, ContactRoles...) {
+		header := "Example " + v + " Details"
+		err = RegisterContactSection(header, v)
+		assert.Nil(t, err, v)
+		defer func() {
+			contactSectionsMu.Lock()
+			delete(contactSections, clearKeyName(header))
+			contactSectionsMu.Unlock()
+		}()
+
+		whoisInfo, err = Parse("Domain Name: example.com\n\n" + header + ":\nName: Example Contact\n\n" +
+			"Name Server: ns1.example.com")
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.ContactByRole(v).Name, "Example Contact", v)
+	}
+
+	err = RegisterContactSection("Domain Reseller Details", "reseller")
+	assert.Equal(t, err, ErrUnknownContactRole)
+	contactSectionsMu.RLock()
+	_, ok := contactSections["domain reseller details"]
+	contactSectionsMu.RUnlock()
+	assert.False(t, ok)
+}
+
+func TestParseAll(t *testing.T) {
+	whoisRaw, err := xfile.ReadText("testdata/multiple/com_example.com")
+	assert.Nil(t, err)
+
+	whoisInfos, err := ParseAll(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 3)
+	assert.Equal(t, whoisInfos[0].Domain.Domain, "example.com")
+	assert.Equal(t, whoisInfos[0].Domain.Status, []string{"clientDeleteProhibited",
+		"clientTransferProhibited", "clientUpdateProhibited"})
+	assert.Equal(t, whoisInfos[1].Domain.Domain, "example.net")
+	assert.Equal(t, whoisInfos[1].Domain.ID, "4191_DOMAIN_NET-VRSN")
+	assert.Equal(t, whoisInfos[1].Domain.Status, []string{"clientDeleteProhibited"})
+	assert.Equal(t, whoisInfos[2].Domain.Domain, "example.org")
+	assert.Equal(t, whoisInfos[2].Domain.Status, []string{"serverTransferProhibited"})
+	assert.Equal(t, whoisInfos[2].Domain.NameServers, []string{"a.iana-servers.net"})
+
+	// the expected domain is not checked against the records of other domains
+	whoisInfos, err = ParseAll(whoisRaw, WithExpectedDomain("example.net"))
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 3)
+	assert.Equal(t, whoisInfos[1].Domain.Domain, "example.net")
+
+	// single record with disclaimer before a dashed line
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/edu_unm.edu")
+	assert.Nil(t, err)
+
+	whoisInfos, err = ParseAll(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 1)
+	assert.Equal(t, whoisInfos[0].Domain.Domain, "unm.edu")
+
+	// variant names of one record
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/xn--fiqs8s_xn--vhq524a.xn--fiqs8s")
+	assert.Nil(t, err)
+
+	whoisInfos, err = ParseAll(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 1)
+
+	// no domain name key
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/jp_google.jp")
+	assert.Nil(t, err)
+
+	whoisInfos, err = ParseAll(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 1)
+	assert.Equal(t, whoisInfos[0].Domain.Domain, "google.jp")
+
+	// the dashed section underline of .mo is in one record
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/mo_moo.mo")
+	assert.Nil(t, err)
+
+	whoisInfos, err = ParseAll(whoisRaw)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 1)
+	assert.NotNil(t, whoisInfos[0].Registrant)
+
+	// the parsed records are kept if one is not found
+	whoisInfos, err = ParseAll(`Domain Name: EXAMPLE.COM
+Name Server: A.IANA-SERVERS.NET
+
+------------------------------------------------------------
+
+No match for "EXAMPLE-NOT-FOUND.COM".
+
+------------------------------------------------------------
+
+Domain Name: EXAMPLE.NET
+Name Server: B.IANA-SERVERS.NET`)
+	assert.Nil(t, err)
+	assert.Len(t, whoisInfos, 2)
+	assert.Equal(t, whoisInfos[0].Domain.Domain, "example.com")
+	assert.Equal(t, whoisInfos[1].Domain.Domain, "example.net")
+
+	_, err = ParseAll("No match for \"EXAMPLE-NOT-FOUND.COM\".")
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseAllSingleRecord(t *testing.T) {
+	dirs, err := xfile.ListDir(noterrorDir, xfile.TypeFile, -1)
+	assert.Nil(t, err)
+
+	for _, v := range dirs {
+		if v.Name == "README.md" || strings.HasSuffix(v.Name, ".json") || strings.HasSuffix(v.Name, ".pre") {
+			continue
+		}
+
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v.Name)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, v.Name)
+
+		whoisInfos, err := ParseAll(whoisRaw)
+		assert.Nil(t, err, v.Name)
+		assert.Equal(t, whoisInfos[0], whoisInfo, v.Name)
+	}
+}
+
+func TestParseEPPFastPath(t *testing.T) {
+	dirs, err := xfile.ListDir(noterrorDir, xfile.TypeFile, -1)
+	assert.Nil(t, err)
+
+	fastPathed := 0
+	for _, v := range dirs {
+		if v.Name == "README.md" || strings.HasSuffix(v.Name, ".json") || strings.HasSuffix(v.Name, ".pre") {
+			continue
+		}
+
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v.Name)
+		assert.Nil(t, err)
+
+		if isEPPWhois(whoisRaw) {
+			fastPathed++
+		}
+
+		fastInfo, fastErr := parseDomainWhois(whoisRaw, newOptions())
+		genericInfo, genericErr := parseDomainWhois(whoisRaw, options{})
+		assert.Equal(t, fastErr, genericErr, v.Name)
+		assert.Equal(t, fastInfo, genericInfo, v.Name)
+	}
+
+	assert.Gt(t, fastPathed, 0)
+}
+
+func BenchmarkParseGeneric(b *testing.B) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = parseDomainWhois(whoisRaw, options{})
+	}
+}
+
+func BenchmarkParseEPP(b *testing.B) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = parseDomainWhois(whoisRaw, newOptions())
+	}
+}
+
+func BenchmarkParseFamily(b *testing.B) {
+	families := []struct {
+		name string
+		file string
+	}{
+		{"gTLD", "com_google.com"},
+		{"JP", "jp_google.co.jp"},
+		{"HK", "hk_google.hk"},
+		{"TW", "tw_google.com.tw"},
+		{"FR", "fr_google.fr"},
+		{"EDU", "edu_cornell.edu"},
+	}
+
+	for _, v := range families {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v.file)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(v.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(whoisRaw)))
+			for i := 0; i < b.N; i++ {
+				_, _ = Parse(whoisRaw)
+			}
+		})
+	}
+}
+
+func TestParseLineBreaks(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	expected, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+
+	for _, lineBreak := range []string{"\r\n", "\r"} {
+		whoisInfo, err := Parse(strings.ReplaceAll(whoisRaw, "\n", lineBreak))
+		assert.Nil(t, err)
+		assert.Equal(t, whoisInfo, expected, fmt.Sprintf("%q", lineBreak))
+	}
+
+	whoisInfo, err := Parse("Domain Name: example.com\rName Server: ns1.example.com\rName Server: ns2.example.com\r")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.com")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.com", "ns2.example.com"})
+}
+
+func TestParseLongLine(t *testing.T) {
+	// a 10MB single line without line break is rejected
+	whoisRaw := "Domain Name: example.com\nRegistrar: " + strings.Repeat("A", 10<<20)
+
+	_, err := Parse(whoisRaw)
+	assert.Equal(t, err, ErrLineTooLong)
+	_, err = ParseDomainWhois(whoisRaw)
+	assert.Equal(t, err, ErrLineTooLong)
+	_, err = ParseAll(whoisRaw)
+	assert.Equal(t, err, ErrLineTooLong)
+	_, err = Parse(strings.Replace(whoisRaw, "\n", "\r", 1))
+	assert.Equal(t, err, ErrLineTooLong)
+
+	// the response longer than the cap is parsed if every line is not
+	whoisRaw = "Domain Name: example.com\nRegistrar: Example Registrar\n" +
+		strings.Repeat("Remarks: "+strings.Repeat("A", 1000)+"\n", 2000)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.com")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar")
+
+	assert.Nil(t, checkLineLength(strings.Repeat("A", maxLineLength)))
+	assert.Equal(t, checkLineLength(strings.Repeat("A", maxLineLength+1)), ErrLineTooLong)
+	assert.Nil(t, checkLineLength(strings.Repeat("A", maxLineLength)+"\n"+strings.Repeat("A", maxLineLength)))
+}
+
+func TestParseWhoisFooter(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/app_google.app")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"clientDeleteProhibited",
+		"clientTransferProhibited", "clientUpdateProhibited"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.google.com",
+		"ns2.google.com", "ns3.google.com", "ns4.google.com"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "2015-06-25T18:20:23Z")
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2023-04-27T09:32:11Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2024-06-25T18:20:23Z")
+	assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime)
+
+	// the record following a registry footer is still parsed
+	whoisInfo, err = Parse(`Domain Name: example.com
+Registrar: Example Registrar
+>>> Last update of WHOIS database: 2023-10-16T08:14:27Z <<<
+Status: notice only
+Domain Name: example.com
+Registrant Email: admin@example.com`)
+	assert.Nil(t, err)
+	assert.Zero(t, whoisInfo.Domain.Status)
+	assert.Equal(t, whoisInfo.Registrant.Email, "admin@example.com")
+}
+
+func TestParseSponsoringRegistrarIANAID(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/cc_msn.cc")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.ID, "299")
+
+	whoisInfo, err = Parse(`Domain Name: example.com
+Registry Domain ID: 12345_DOMAIN_COM-VRSN
+Registrar: Example Registrar, Inc.
+Sponsoring Registrar IANA ID: 299
+Registrar IANA ID: 9999`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.ID, "299")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar, Inc.")
+}
+
+func TestParseNameServerIPs(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ru_yandex.ru")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.yandex.ru", "ns2.yandex.ru", "ns9.z5h64q92x9.net"})
+	assert.Equal(t, whoisInfo.Domain.NameServerIPs, map[string][]string{
+		"ns1.yandex.ru": {"213.180.193.1", "2a02:6b8::1"},
+		"ns2.yandex.ru": {"93.158.134.1", "2a02:6b8:0:1::1"},
+	})
+
+	whoisInfo, err = Parse(`Domain Name: example.com
+Name Server: NS1.EXAMPLE.COM 192.0.2.1
+Name Server: ns2.example.com (192.0.2.2)
+Name Server: ns3.example.com (192.0.2.3, 2001:DB8::3)
+Name Server: ns4.example.com [2001:db8::4]
+Name Server: ns5.example.net`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.com", "ns2.example.com",
+		"ns3.example.com", "ns4.example.com", "ns5.example.net"})
+	assert.Equal(t, whoisInfo.Domain.NameServerIPs, map[string][]string{
+		"ns1.example.com": {"192.0.2.1"},
+		"ns2.example.com": {"192.0.2.2"},
+		"ns3.example.com": {"192.0.2.3", "2001:db8::3"},
+		"ns4.example.com": {"2001:db8::4"},
+	})
+
+	whoisInfo, err = Parse(`Domain Name: example.com
+Name Server: ns1.example.com
+Name Server: ns2.example.com`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.com", "ns2.example.com"})
+	assert.Zero(t, whoisInfo.Domain.NameServerIPs)
+}
+
+func TestParseNameEmailForwarding(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/name_john.smith.name")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "john.smith.name")
+	assert.Equal(t, whoisInfo.Domain.Name, "john.smith")
+	assert.Equal(t, whoisInfo.Domain.Extension, "name")
+	assert.Equal(t, whoisInfo.Registrar.ID, "81")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"email_forwarding":    "JOHN@SMITH.NAME",
+		"email_forwarding_id": "48211937_EMAIL_NAME-VRSN",
+		"forwarded_to":        "john.smith@example.com",
+	})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/name_google.name")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Name, "google")
+	assert.Zero(t, whoisInfo.Extensions)
+}
+
+func TestParseSponsoredTLD(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/aero_vas.aero")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D920695-AERO")
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "2010-07-07T19:23:48Z")
+	assert.Equal(t, whoisInfo.Registrar.Name, "101domain GRS Limited")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{"ens_auth_id": "ENSR-5861"})
+
+	// the sponsor identity fields are kept as extensions, not the contact fields
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/aero_example-member.aero")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXA-1001")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Airways AG")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"ens_auth_id":        "ENSR-20931",
+		"member_id":          "AERO-M-20931",
+		"aviation_community": "Airline",
+	})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/jobs_example.jobs")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXJ-1001")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Talent Acquisition")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Staffing Inc.")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"association":             "Society for Human Resource Management",
+		"association_member_id":   "SHRM-0048213",
+		"industry_classification": "Staffing and Recruiting",
+	})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/travel_example-tours.travel")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D1234567-TRAVEL")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Tours Ltd")
+	assert.Equal(t, whoisInfo.Registrar.Name, "EnCirca, Inc.")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{"uin": "1234567890"})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/pro_example-cpa.pro")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D107300000000028341-LRMS")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Jane Example")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Accounting LLP")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"profession":              "Certified Public Accountant",
+		"license_number":          "CPA-0123456",
+		"licensing_authority":     "New York State Board of Public Accountancy",
+		"licensing_authority_url": "https://www.op.nysed.gov/professions/cpa",
+		"jurisdiction":            "US-NY",
+	})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/coop_slb.coop")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Country, "ES")
+	assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime)
+	assert.Zero(t, whoisInfo.Extensions)
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/coop_example-verified.coop")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Farmers Cooperative")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Gandi SAS")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{"verification_status": "Verified"})
+
+	for _, v := range []string{"aero", "coop", "museum", "jobs", "pro", "travel"} {
+		whoisRaw, err = xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+	}
+}
+
+func TestParseAdultTLD(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/xxx_example.xxx")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D1234567-AGRS")
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "2011-12-06T15:04:31Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2024-12-06T15:04:31Z")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Media Ltd")
+	assert.Equal(t, whoisInfo.Registrant.Email, "hostmaster@example.xxx")
+	assert.Equal(t, whoisInfo.Technical.Email, "tech@example-registrar.com")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"membership_id":          "XXX-M-10452",
+		"membership_status":      "Verified",
+		"membership_association": "Sponsored Community",
+	})
+
+	for _, v := range []string{"xxx", "sex", "sexy"} {
+		whoisRaw, err = xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+	}
+}
+
+func TestParseFreenom(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ml_example.ml")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Active"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "04/07/2015")
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format("2006-01-02"), "2015-04-07")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format("2006-01-02"), "2025-04-07")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Mali SARL")
+	assert.Equal(t, whoisInfo.Registrant.Province, "District de Bamako")
+	assert.Equal(t, whoisInfo.Billing.Name, "Awa Keita")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example-hosting.de")
+
+	// month first dates
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/tk_zcore.tk")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "01/03/2022")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format("2006-01-02"), "2022-01-03")
+	assert.Equal(t, whoisInfo.Registrant.Country, "Ukraine")
+
+	// free domain with the registry as owner
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/ga_example.ga")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "My GA administrator")
+	assert.Equal(t, whoisInfo.Registrant.Emails, []string{"abuse@freenom.com", "copyright@freenom.com"})
+
+	// indented owner block with an empty organization in the admin block
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/tk_example-owner.tk")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "Jan de Vries")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Owner B.V.")
+	assert.Equal(t, whoisInfo.Registrant.Email, "owner@example-owner.tk")
+	assert.Equal(t, whoisInfo.Administrative.Name, "Piet Jansen")
+	assert.Equal(t, whoisInfo.Administrative.Organization, "")
+	assert.Equal(t, whoisInfo.Administrative.Email, "admin@example-owner.tk")
+
+	for _, v := range []string{"tk", "ml", "ga"} {
+		whoisRaw, err = xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+	}
+}
+
+func TestParseDE(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/de_google.de")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"connect"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.google.com", "ns2.google.com",
+		"ns3.google.com", "ns4.google.com"})
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/de_likexian-have-no-money-to-register.de")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+
+	// the name is not allowed by the registry, like the leading hyphen
+	_, err = Parse("Domain: -likexian.de\nStatus: invalid\n")
+	assert.Equal(t, err, ErrInvalidDomain)
+	assert.NotEqual(t, err, ErrNotFoundDomain)
+}
+
+func TestParseNZ(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/nz_gre.nz")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "gre.nz")
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"registered"})
+
+	tests := map[string][]string{
+		"210 PendingRelease": {"pendingRelease"},
+		"230 Locked":         {"Locked"},
+	}
+
+	for k, v := range tests {
+		whoisInfo, err = Parse(strings.Replace(whoisRaw, "200 Active", k, 1))
+		assert.Nil(t, err, k)
+		assert.Equal(t, whoisInfo.Domain.Status, v, k)
+	}
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/nz_likexian-have-no-money-to-register.nz")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParsePH(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ph_example.ph")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "1012345-DOTPH")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2026-05-17T08:12:00Z")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-hosting.ph", "ns2.example-hosting.ph"})
+	assert.Equal(t, whoisInfo.Registrar.Name, "dotPH Domain Registry")
+	assert.Equal(t, whoisInfo.Registrant.Name, "REDACTED FOR PRIVACY")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Philippines Inc.")
+	assert.Equal(t, whoisInfo.Registrant.Province, "Metro Manila")
+	assert.True(t, whoisInfo.Registrant.IsRedacted())
+	assert.True(t, whoisInfo.Administrative.IsRedacted())
+	assert.Equal(t, whoisInfo.Technical.Name, "Juan Dela Cruz")
+	assert.Equal(t, whoisInfo.Technical.PhoneE164, "+63288881234")
+	assert.False(t, whoisInfo.Technical.IsRedacted())
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/ph_likexian-have-no-money-to-register.ph")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseTO(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/to_google.to")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "google.to")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.google.com", "ns2.google.com",
+		"ns3.google.com", "ns4.google.com"})
+
+	// bare domain name
+	whoisInfo, err = Parse("EXAMPLE.TO\n")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.to")
+	assert.Equal(t, whoisInfo.Domain.Extension, "to")
+	assert.Zero(t, whoisInfo.Domain.NameServers)
+
+	whoisInfo, err = Parse("Tonic whoisd V1.1\nexample.to is not available")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.to")
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/to_likexian-have-no-money-to-register.to")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseCAT(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/cat_example.cat")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "245187-D")
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ok"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2008-04-22T09:30:00Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2024-04-22T09:30:00Z")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar, S.L.")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Associació Cultural Exemple")
+	assert.Equal(t, whoisInfo.Registrant.Email, "info@example.cat")
+	assert.Equal(t, whoisInfo.Administrative.Email, "marta@example.cat")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-registrar.cat", "ns2.example-registrar.cat"})
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"language":     "ca",
+		"intended_use": "Web site of a Catalan cultural association, publishing its activities and agenda in Catalan",
+		"eligibility":  "Catalan Linguistic and Cultural Community",
+	})
+
+	// Catalan labels
+	whoisInfo, err = Parse(strings.NewReplacer("Intended Use:", "Ús previst:",
+		"Domain Language:", "Idioma:").Replace(whoisRaw))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Extensions["language"], "ca")
+	assert.Contains(t, whoisInfo.Extensions["intended_use"], "cultural association")
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/cat_likexian-have-no-money-to-register.cat")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseNordicIslands(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ax_example.ax")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"active"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.ax", "ns2.example.ax"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2007-03-12T00:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2025-03-12T00:00:00Z")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Ålcom Ab")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Ab")
+	assert.Equal(t, whoisInfo.Registrant.Street, "Storagatan 1, 22100 Mariehamn")
+	assert.Equal(t, whoisInfo.Registrant.Email, "info@example.ax")
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/fo_example.fo")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.fo", "ns2.example.fo"})
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2025-05-18")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar P/F")
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXF1-FO")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Example P/F")
+	assert.Equal(t, whoisInfo.Registrant.Country, "FO")
+	assert.Equal(t, whoisInfo.Registrant.Email, "info@example.fo")
+	assert.Equal(t, whoisInfo.Administrative.ID, "EXF2-FO")
+	assert.Equal(t, whoisInfo.Technical.ID, "EXF3-FO")
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/gl_example.gl")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ok"})
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2025-07-02T08:15:00Z")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar A/S")
+	assert.Equal(t, whoisInfo.Registrant.City, "Nuuk")
+
+	for _, v := range []string{"ax", "fo", "gl"} {
+		whoisRaw, err = xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+	}
+}
+
+func TestParseEE(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ee_example-signed.ee")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"serverHold", "serverTransferProhibited", "serverUpdateProhibited"})
+	assert.True(t, whoisInfo.Domain.DNSSec)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-signed.ee", "ns2.example-signed.ee"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2015-03-02T11:20:31+02:00")
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2023-09-14 08:05:12 +03:00")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2024-03-02")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2024-03-02T00:00:00Z")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Zone Media OÜ")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Example OÜ")
+
+	// unsigned domain
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/ee_telia.ee")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ok"})
+	assert.False(t, whoisInfo.Domain.DNSSec)
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2020-08-03 00:41:44 +03:00")
+}
+
+func TestParseMicroStates(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ad_example.ad")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Registered"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.ad", "ns2.example.ad"})
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2004-03-15T00:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2025-03-15T00:00:00Z")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example SA")
+	assert.Equal(t, whoisInfo.Registrant.Country, "AD")
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/mc_example.mc")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.mc", "ns2.example.mc"})
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2025-04-22")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example SAM")
+	assert.Equal(t, whoisInfo.Administrative.Name, "Jean Dupont")
+	assert.Equal(t, whoisInfo.Administrative.Email, "admin@example.mc")
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/sm_example.sm")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Active"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.sm", "ns2.example.sm"})
+	assert.Equal(t, whoisInfo.Domain.UpdatedDateInTime.Format(time.RFC3339), "2024-01-12T00:00:00Z")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Srl")
+	assert.Equal(t, whoisInfo.Registrant.Street, "Via Example 1, 47890 San Marino")
+	assert.Equal(t, whoisInfo.Registrant.Country, "SM")
+	assert.Equal(t, whoisInfo.Technical.Name, "Mario Rossi")
+	assert.Equal(t, whoisInfo.Technical.Email, "mario.rossi@example.sm")
+
+	for _, v := range []string{"ad", "mc", "sm"} {
+		whoisRaw, err = xfile.ReadText(notfoundDir + "/" + v + "_likexian-have-no-money-to-register." + v)
+		assert.Nil(t, err)
+		_, err = Parse(whoisRaw)
+		assert.Equal(t, err, ErrNotFoundDomain, v)
+	}
+}
+
+func TestParseJP(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/jp_example-signed.jp")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"To be suspended"})
+	assert.Equal(t, whoisInfo.Domain.NormalizedStatus(), []string{"redemptionPeriod"})
+	assert.True(t, whoisInfo.Domain.DNSSec)
+	assert.True(t, whoisInfo.Domain.IsExpired())
+
+	// the empty signing key is unsigned
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/jp_google.jp")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Active"})
+	assert.Equal(t, whoisInfo.Domain.NormalizedStatus(), []string{"ok"})
+	assert.False(t, whoisInfo.Domain.DNSSec)
+
+	// the state of attribute type domain has the expiration date
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/jp_google.co.jp")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"Connected"})
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2024-03-31T00:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.google.com", "ns2.google.com",
+		"ns3.google.com", "ns4.google.com"})
+
+	// the name servers following the key one per line
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/jp_example-ns.jp")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-ns.jp", "ns2.example-ns.jp",
+		"ns3.example-ns.net"})
+	assert.Equal(t, whoisInfo.Administrative.Street, "Shibuya-ku, 2-21-1 Shibuya")
+}
+
+func TestParseEDULayout(t *testing.T) {
+	// contacts without phone or organization line
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/edu_example-college.edu")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example College")
+	assert.Equal(t, whoisInfo.Registrant.Street, "100 College Avenue, Springfield, IL 62701, US")
+	assert.Equal(t, whoisInfo.Registrant.Email, "hostmaster@example-college.edu")
+	assert.Equal(t, whoisInfo.Administrative.Name, "Jane Doe")
+	assert.Equal(t, whoisInfo.Administrative.Phone, "")
+	assert.Equal(t, whoisInfo.Administrative.Email, "jane.doe@example-college.edu")
+	assert.Equal(t, whoisInfo.Technical.Organization, "")
+	assert.Equal(t, whoisInfo.Technical.Street, "100 College Avenue, Springfield, IL 62701, US")
+	assert.Equal(t, whoisInfo.Technical.Phone, "+1.2175550100")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example-college.edu")
+}
+
+func TestParseGOV(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/gov_example-agency.gov")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ACTIVE"})
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Federal Agency")
+	assert.Equal(t, whoisInfo.Registrant.Province, "DC")
+	assert.Equal(t, whoisInfo.Extensions["security_contact_email"], "security@example-agency.gov")
+
+	// no contacts at all
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/gov_fda.gov")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "fda.gov")
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ACTIVE"})
+	assert.Zero(t, whoisInfo.Registrant)
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/gov_likexian-have-no-money-to-register.gov")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseContactEmails(t *testing.T) {
+	whoisInfo, err := Parse(`Domain Name: example.com
+Registrant Name: Example Owner
+Registrant Email: Owner@example.com, billing@example.com
+Registrant Email: owner@example.com; legal@example.com
+Admin Email: admin@example.com`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Email, "owner@example.com")
+	assert.Equal(t, whoisInfo.Registrant.Emails, []string{"owner@example.com", "billing@example.com", "legal@example.com"})
+	assert.Equal(t, whoisInfo.Administrative.Email, "admin@example.com")
+	assert.Equal(t, whoisInfo.Administrative.Emails, []string{"admin@example.com"})
+
+	// the value that is not an email address is not in emails
+	whoisInfo, err = Parse(`Domain Name: example.com
+Registrant Email: REDACTED FOR PRIVACY
+Admin Email: https://example.com/contact, admin@example.com`)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Email, "redacted for privacy")
+	assert.Zero(t, whoisInfo.Registrant.Emails)
+	assert.Equal(t, whoisInfo.Administrative.Email, "https://example.com/contact, admin@example.com")
+	assert.Zero(t, whoisInfo.Administrative.Emails)
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ch_switch.ch")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.Email, "support-en@support.gandi.net")
+	assert.Equal(t, whoisInfo.Registrar.Emails, []string{"support-en@support.gandi.net", "support-fr@support.gandi.net"})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, dir := range []string{noterrorDir, notfoundDir} {
+		files, err := xfile.ListDir(dir, xfile.TypeFile, -1)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, v := range files {
+			if strings.HasSuffix(v.Name, ".json") || strings.HasSuffix(v.Name, ".pre") || v.Name == "README.md" {
+				continue
+			}
+			data, err := xfile.ReadText(dir + "/" + v.Name)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(data)
+		}
+	}
+
+	f.Add("domain: example.com\n:\nname:\n")
+	f.Add("Domain Name: example.com\nRegistrant:\n")
+	f.Add("Domain Name: example.com\nName Server: (192.0.2.1)\n")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		whoisInfo, err := Parse(text)
+		if err == nil && whoisInfo.Domain == nil && whoisInfo.IP == nil && whoisInfo.AS == nil {
+			t.Errorf("Parse returned neither result nor error for %q", text)
+		}
+	})
+}
+
+func TestParseCombinedContactLine(t *testing.T) {
+	whoisInfo, err := Parse("Domain Name: example.com\n" +
+		"Registrant Name: John Doe / +1.5551234567 / John@Example.com\n" +
+		"Admin Organization: Example, Inc. <admin@example.com>\n" +
+		"Tech Name: tech@example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "John Doe")
+	assert.Equal(t, whoisInfo.Registrant.Phone, "+1.5551234567")
+	assert.Equal(t, whoisInfo.Registrant.Email, "john@example.com")
+	assert.Equal(t, whoisInfo.Administrative.Organization, "Example, Inc.")
+	assert.Equal(t, whoisInfo.Administrative.Email, "admin@example.com")
+	assert.Equal(t, whoisInfo.Technical.Name, "tech@example.com")
+	assert.Zero(t, whoisInfo.Technical.Email)
+}
+
+func TestParseOrganizationLocal(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/tw_specialized.com.tw")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Specialized Bicycle Components Taiwan Limited")
+	assert.Equal(t, whoisInfo.Registrant.OrganizationLocal, "斯貝特有限公司")
+
+	whoisInfo, err = Parse("Domain Name: example.com\n" +
+		"Registrant Organization: Example Limited\n" +
+		"Registrant Organization: 示例有限公司\n" +
+		"Registrant Organization: Another Limited\n" +
+		"Admin Organization: Example Limited")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Limited")
+	assert.Equal(t, whoisInfo.Registrant.OrganizationLocal, "示例有限公司")
+	assert.Zero(t, whoisInfo.Administrative.OrganizationLocal)
+}
+
+func TestParseMO(t *testing.T) {
+	for _, v := range []string{"moo.mo", "yp.mo", "example-dmy.mo"} {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/mo_" + v)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err)
+		assert.NotZero(t, whoisInfo.Domain.CreatedDateInTime, v)
+		assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime, v)
+	}
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/mo_example-dmy.mo")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2012-03-07T09:15:40Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2027-03-07T00:00:00Z")
+}
+
+func TestParseProseFooter(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-footer.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-footer.com", "ns2.example-footer.com"})
+
+	assert.True(t, isWhoisProse("Example Registrar reserves the right to modify these terms at any time. By submitting"))
+	assert.False(t, isWhoisProse("3F.-2, No.187, Zhongyang Rd., Xindian Dist, New Taipei City"))
+	assert.False(t, isWhoisProse("ns1.example-footer.com"))
+}
+
+func TestParseTEL(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/tel_example-naptr.tel")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"a0.cth.dns.nic.tel", "d0.cth.dns.nic.tel"})
+	assert.Equal(t, whoisInfo.Registrant.ID, "C2718281-TEL")
+	assert.Equal(t, whoisInfo.Registrant.Email, "owner@example-naptr.tel")
+	assert.Equal(t, whoisInfo.Extensions["whois_type"], "limited")
+	assert.Equal(t, whoisInfo.Extensions["naptr"], `100 10 "u" "E2U+web:http" "!^.*$!http://www.example-naptr.com!" ., `+
+		`100 20 "u" "E2U+voice:tel" "!^.*$!tel:+44.2071234567!" .`)
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/tel_google.tel")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Google Inc.")
+	assert.Zero(t, whoisInfo.Extensions)
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/tel_likexian-have-no-money-to-register.tel")
+	assert.Nil(t, err)
+
+	_, err = Parse(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
+
+func TestParseCH(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/ch_google.ch")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "31 May 1999")
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "1999-05-31T00:00:00Z")
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/ch_switch.ch")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "1996-01-01T00:00:00Z")
+}
+
+func TestParseProvinceAliases(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/jp_example-prefecture.jp")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Administrative.Province, "Tokyo")
+
+	whoisInfo, err = Parse("Domain Name: example.com\n" +
+		"Registrant State: CA\n" +
+		"Admin Region: Bavaria\n" +
+		"Tech Oblast: Almaty\n" +
+		"Billing Province: Ontario")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Province, "CA")
+	assert.Equal(t, whoisInfo.Administrative.Province, "Bavaria")
+	assert.Equal(t, whoisInfo.Technical.Province, "Almaty")
+	assert.Equal(t, whoisInfo.Billing.Province, "Ontario")
+}
+
+func TestParseMIMEEncodedValue(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-mime.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "François Dupré")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Société Générale d'Exemple")
+	assert.Equal(t, whoisInfo.Registrant.Street, "1 rue de l'Exemple")
+
+	tests := map[string]string{
+		"=?UTF-8?Q?Soci=C3=A9t=C3=A9?=":    "Société",
+		"=?utf-8?b?U29jacOpdMOp?= Anonyme": "Société Anonyme",
+		"=?ISO-8859-1?Q?Caf=E9?=":          "Café",
+		"=?KOI8-R?Q?=F0=D2=C9=D7=C5=D4?=":  "=?KOI8-R?Q?=F0=D2=C9=D7=C5=D4?=",
+		"a=?b is not encoded":              "a=?b is not encoded",
+		"https://example.com/?q=1&r=?x?=":  "https://example.com/?q=1&r=?x?=",
+	}
+
+	for k, v := range tests {
+		assert.Equal(t, decodeMIMEWords(k), v, k)
+	}
+}
+
+func TestParseITNameServerIPs(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/it_example-glue.it")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers,
+		[]string{"ns1.example-glue.it", "ns2.example-glue.it", "ns.example-hosting.net"})
+	assert.Equal(t, whoisInfo.Domain.NameServerIPs, map[string][]string{
+		"ns1.example-glue.it": {"192.0.2.1"},
+		"ns2.example-glue.it": {"198.51.100.2", "2001:db8::2"},
+	})
+}
+
+func TestParseUnicodeName(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/se_xn--fl-fka.se")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Name, "xn--fl-fka")
+	assert.Equal(t, whoisInfo.Domain.NameUnicode, "föl")
+	assert.Equal(t, whoisInfo.Domain.Extension, "se")
+	assert.Equal(t, whoisInfo.Domain.Punycode, "xn--fl-fka.se")
+
+	// the invalid punycode keeps the A-label name without the Unicode one
+	whoisInfo, err = Parse("Domain Name: xn--0000h.com\nName Server: ns1.example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Name, "xn--0000h")
+	assert.Zero(t, whoisInfo.Domain.NameUnicode)
+	assert.Equal(t, whoisInfo.Domain.Domain, "xn--0000h.com")
+
+	whoisInfo, err = Parse("Domain Name: example.com\nName Server: ns1.example.com")
+	assert.Nil(t, err)
+	assert.Zero(t, whoisInfo.Domain.NameUnicode)
+
+	assert.Equal(t, unicodeDomain("xn--j1ay"), "кц")
+	assert.Equal(t, unicodeDomain("XN--FL-FKA"), "föl")
+	assert.Equal(t, unicodeDomain("xn--abc-"), "")
+	assert.Equal(t, unicodeDomain("xn--zz"), "")
+}
+
+func TestParseINTAddress(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/int_example-blocks.int")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-blocks.int", "ns2.example-blocks.int"})
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Intergovernmental Organization")
+	assert.Equal(t, whoisInfo.Registrant.Street, "Example House, 12 Avenue de la Paix, Geneva 1211, Switzerland")
+	assert.Equal(t, whoisInfo.Administrative.Street, "Example House, 12 Avenue de la Paix, Geneva 1211, Switzerland")
+	assert.Equal(t, whoisInfo.Administrative.Email, "admin@example-blocks.int")
+	assert.Equal(t, whoisInfo.Technical.Street, "Example Hosting SA, Rue du Rhone 1, Geneva 1204, Switzerland")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example-blocks.int")
+}
+
+func TestParseRUPaidTill(t *testing.T) {
+	tests := map[string]string{
+		"ru_google.ru":         "2020-03-04T21:00:00Z",
+		"ru_example-dotted.ru": "2025-06-15T00:00:00Z",
+	}
+
+	for k, v := range tests {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, k)
+		assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime, k)
+		assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), v, k)
+	}
+}
+
+func TestParseRemarks(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/at_0wnz.at")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Remarks, []string{"89.185.109.153", "89.185.109.154", "54.38.158.217", "78.47.81.186"})
+
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/re_git.re")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, len(whoisInfo.Domain.Remarks), 16)
+	assert.Equal(t, whoisInfo.Domain.Remarks[1], "While the registrar knows him/her,")
+	for _, v := range []*Contact{whoisInfo.Registrant, whoisInfo.Administrative, whoisInfo.Technical} {
+		if v != nil {
+			assert.NotContains(t, v.Comment, "WARNING")
+		}
+	}
+
+	whoisInfo, err = Parse("Domain Name: example.com\n" +
+		"Remarks: first note\n" +
+		"Admin Name: Example Admin\n" +
+		"Admin Comment: second note")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Remarks, []string{"first note", "second note"})
+	assert.Equal(t, whoisInfo.Administrative.Name, "Example Admin")
+	assert.Zero(t, whoisInfo.Administrative.Comment)
+}
+
+func TestParseHKGivenFamilyName(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/hk_example-names.hk")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Administrative.Name, "TAI MAN CHAN")
+	assert.Equal(t, whoisInfo.Technical.Name, "SIU MING WONG")
+
+	text := prepareHK("Technical Contact Information:\nGiven name:  .\nFamily name:  CHAN\nEmail:  a@b.hk")
+	assert.Contains(t, text, "Technical Given name: CHAN\n")
+
+	text = prepareHK("Technical Contact Information:\nGiven name:  DNS\nFamily name:  .\n")
+	assert.Contains(t, text, "Technical Given name: DNS")
+	assert.NotContains(t, text, ".")
+}
+
+func TestParseBOM(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-bom.com")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(whoisRaw, "\ufeff"))
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-bom.com")
+	assert.Equal(t, whoisInfo.Domain.ID, "1357924680_DOMAIN_COM-VRSN")
+
+	whoisInfo, err = ParseDomainWhois(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-bom.com")
+
+	whoisPrepare, _ := Prepare(whoisRaw, "com")
+	assert.True(t, strings.HasPrefix(whoisPrepare, "Domain Name:"))
+}
+
+func TestParseFRFamilyDates(t *testing.T) {
+	for _, v := range []string{"tf_google.tf", "yt_google.yt", "pm_google.pm", "wf_google.wf", "re_google.re"} {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + v)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, v)
+		assert.NotZero(t, whoisInfo.Domain.CreatedDateInTime, v)
+		assert.NotZero(t, whoisInfo.Domain.UpdatedDateInTime, v)
+		assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime, v)
+	}
+
+	// the legacy short date format is day first, the anniversary has no year
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/tf_example-legacy.tf")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.CreatedDateInTime.Format(time.RFC3339), "2012-03-05T00:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.UpdatedDateInTime.Format(time.RFC3339), "2019-01-21T00:00:00Z")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2020-03-05T00:00:00Z")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{"anniversary": "05/03"})
+}
+
+func TestParseDNSSECStatus(t *testing.T) {
+	tests := map[string]string{
+		"ee_example-signed.ee": DNSSECStatusSigned,
+		"ch_switch.ch":         DNSSECStatusSigned,
+		"com_google.com":       DNSSECStatusUnsigned,
+		"ro_google.ro":         DNSSECStatusUnsigned,
+		"it_google.it":         DNSSECStatusUnsigned,
+		"kr_google.kr":         DNSSECStatusUnsigned,
+		"at_0wnz.at":           DNSSECStatusUnknown,
+		"aq_asf.aq":            DNSSECStatusUnknown,
+	}
+
+	for k, v := range tests {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, k)
+		assert.Equal(t, whoisInfo.Domain.DNSSECStatus, v, k)
+		assert.Equal(t, whoisInfo.Domain.DNSSec, v == DNSSECStatusSigned, k)
+	}
+}
+
+func TestParseContactIDs(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-ids.com")
+	assert.Nil(t, err)
+
+	for _, v := range []string{whoisRaw, strings.NewReplacer("Registry Registrant ID", "Registrant ID",
+		"Registry Admin ID", "Admin ID", "Registry Billing ID", "Billing ID").Replace(whoisRaw)} {
+		for _, o := range []options{newOptions(), {}} {
+			whoisInfo, err := parseDomainWhois(v, o)
+			assert.Nil(t, err)
+			assert.Equal(t, whoisInfo.Domain.ID, "2345678_DOMAIN_COM-VRSN")
+			assert.Equal(t, whoisInfo.Registrant.ID, "C1001-EXAMPLE")
+			assert.Equal(t, whoisInfo.Administrative.ID, "C1002-EXAMPLE")
+			assert.Equal(t, whoisInfo.Technical.ID, "")
+			assert.Equal(t, whoisInfo.Billing.ID, "C1004-EXAMPLE")
+		}
+	}
+}
+
+func TestParseHTMLWrapped(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-html.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-html.com")
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-html.com", "ns2.example-html.com"})
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Smith & Sons Ltd")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example Registrar, LLC")
+
+	// the domain whois entry point normalizes the same
+	domainInfo, err := ParseDomainWhois(whoisRaw)
+	assert.Nil(t, err)
+	domainInfo.ParserVersion = whoisInfo.ParserVersion
+	assert.Equal(t, domainInfo, whoisInfo)
+
+	domainInfo, err = ParseDomainWhois("\ufeff<html><body><pre>Domain Name: example.com\rName Server: ns1.example.com" +
+		"</pre></body></html>")
+	assert.Nil(t, err)
+	assert.Equal(t, domainInfo.Domain.Domain, "example.com")
+	assert.Equal(t, domainInfo.Domain.NameServers, []string{"ns1.example.com"})
+
+	// the page without pre tags is stripped as a whole
+	whoisInfo, err = Parse("<html><body>Domain Name: example.com<br>Registrar: Example &amp; Co<br/>" +
+		"Name Server: ns1.example.com</body></html>")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example.com")
+	assert.Equal(t, whoisInfo.Registrar.Name, "Example & Co")
+
+	// the plain text with tags in values is not unwrapped
+	assert.Equal(t, unwrapHTML("Domain Name: example.com\nRegistrant Email: <a@example.com>"),
+		"Domain Name: example.com\nRegistrant Email: <a@example.com>")
+}
+
+func TestParseThinWithoutExpiration(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_example-thin.com")
+	assert.Nil(t, err)
+
+	whoisRaw = strings.Replace(whoisRaw, "   Registry Expiry Date: 2026-02-10T16:40:11Z\n", "", 1)
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "")
+	assert.Zero(t, whoisInfo.Domain.ExpirationDateInTime)
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "2012-02-10T16:40:11Z")
+
+	// the footer following an empty expiration key is not taken as the date
+	var warnings []Warning
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "   URL of the ICANN",
+		"   Registrar Registration Expiration Date:\n\nTERMS OF USE\nREDACTED\n   URL of the ICANN", 1), WithWarnings(&warnings))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "")
+	assert.Zero(t, whoisInfo.Domain.ExpirationDateInTime)
+	assert.Equal(t, whoisInfo.Domain.UpdatedDate, "2024-02-11T08:15:02Z")
+
+	found := false
+	for _, v := range warnings {
+		found = found || v.Message == "continued value of date key is not a date"
+	}
+	assert.True(t, found)
+
+	// the date on the line following the key is kept
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "   URL of the ICANN",
+		"   Registrar Registration Expiration Date:\n   2026-02-10\n   URL of the ICANN", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2026-02-10")
+	assert.NotZero(t, whoisInfo.Domain.ExpirationDateInTime)
+}
+
+func TestParseASIACED(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/asia_example-ced.asia")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.ID, "D503300000001234567-AGRS")
+	assert.Equal(t, whoisInfo.Domain.CreatedDate, "2007-11-21T20:47:29Z")
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXCED-1001")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Trading Limited")
+	assert.Equal(t, whoisInfo.Registrant.City, "Hong Kong")
+	assert.Equal(t, whoisInfo.Extensions, map[string]string{
+		"ced_id":                    "EXCED-1001",
+		"ced_place_of_domicile":     "HK",
+		"ced_city":                  "Hong Kong",
+		"ced_legal_form":            "Corporation/Company",
+		"ced_identification_type":   "Certificate of Incorporation",
+		"ced_identification_number": "1234567",
+	})
+}
+
+func TestParseKRKoreanOnly(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/kr_example-korean.kr")
+	assert.Nil(t, err)
+	assert.NotContains(t, whoisRaw, "# ENGLISH")
+
+	for _, v := range []string{LanguageEnglish, LanguageLocal} {
+		whoisInfo, err := Parse(whoisRaw, WithPreferLanguage(v))
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.Domain.Domain, "example-korean.kr", v)
+		assert.Equal(t, whoisInfo.Domain.Extension, "kr", v)
+		assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-korean.kr", "ns2.example-korean.kr"}, v)
+		assert.Equal(t, whoisInfo.Domain.ExpirationDateInTime.Format(time.RFC3339), "2020-03-02T00:00:00Z", v)
+		assert.Equal(t, whoisInfo.Domain.DNSSECStatus, DNSSECStatusUnsigned, v)
+		assert.Equal(t, whoisInfo.Registrant.Name, "예시주식회사", v)
+		assert.Equal(t, whoisInfo.Registrar.Name, "(주)후이즈(http://whois.co.kr)", v)
+		assert.Equal(t, whoisInfo.Administrative.Email, "hostmaster@example-korean.kr", v)
+	}
+}
+
+func TestParseMinimalOKStatus(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/aq_example-minimal.aq")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "example-minimal.aq")
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"ok"})
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example-minimal.aq", "ns2.example-minimal.aq"})
+
+	for _, v := range []string{"ok", "OK", "Ok.", "\"ok\"", "ok (active)"} {
+		whoisInfo, err = Parse("domain: example.aq\nstatus: " + v + "\n")
+		assert.Nil(t, err, v)
+		assert.Equal(t, whoisInfo.Domain.Status, []string{"ok"}, v)
+	}
+
+	// the other status codes keep their case
+	whoisInfo, err = Parse("Domain Name: example.com\nDomain Status: clientHold.\n")
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Status, []string{"clientHold"})
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestPhoneE164(t *testing.T) {
+	tests := []struct {
+		phone   string
+		country string
+		out     string
+	}{
+		{"+1.5551234567", "", "+15551234567"},
+		{"+1.5551234567", "FR", "+15551234567"},
+		{"(555) 123-4567", "US", "+15551234567"},
+		{"1 555 123 4567", "US", "+15551234567"},
+		{"(555) 123-4567", "United States (US)", "+15551234567"},
+		{"(555) 123-4567", "U.S.A.", "+15551234567"},
+		{"+44 20 1234 5678", "", "+442012345678"},
+		{"+44 (0)20 1234 5678", "GB", "+442012345678"},
+		{"020 1234 5678", "GB", "+442012345678"},
+		{"01 70 37 76 61", "FR (FRANCE)", "+33170377661"},
+		{"06 941 88 688", "IT", "+390694188688"},
+		{"8 495 123 45 67", "RU", "+74951234567"},
+		{"0044 20 1234 5678", "", "+442012345678"},
+		{"82.25319000", "", "+8225319000"},
+		{"+49.68416984x200", "", "+4968416984"},
+		{"+86.2862778877 ext 8359", "CN", "+862862778877"},
+		{"+39 06941 88 688 (Please include country prefix)", "", "+390694188688"},
+		{"(555) 123-4567", "", ""},
+		{"(555) 123-4567", "Finland", ""},
+		{"+86.0216976800068028", "", ""},
+		{"+01 2083895740", "", ""},
+		{"REDACTED FOR PRIVACY", "US", ""},
+		{"", "US", ""},
+	}
+
+	for _, v := range tests {
+		assert.Equal(t, phoneE164(v.phone, v.country), v.out, v.phone)
+	}
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/hk_ibm.hk")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.Phone, "+852 2319 1313")
+	assert.Equal(t, whoisInfo.Registrar.PhoneE164, "+85223191313")
+	assert.Equal(t, whoisInfo.Administrative.Phone, "+1-9147654227")
+	assert.Equal(t, whoisInfo.Administrative.PhoneE164, "+19147654227")
+}
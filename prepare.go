@@ -29,8 +29,13 @@ import (
 )
 
 // Prepare do prepare the whois info for parsing
-func Prepare(text, ext string) (string, bool) { //nolint:cyclop
-	text = strings.Replace(text, "\r", "", -1)
+func Prepare(text, ext string) (string, bool) {
+	return prepare(text, ext, newOptions())
+}
+
+// prepare do prepare the whois info for parsing with the parsing options
+func prepare(text, ext string, o options) (string, bool) { //nolint:cyclop
+	text = fixLineBreaks(stripBOM(text))
 	text = strings.Replace(text, "\t", " ", -1)
 	text = strings.TrimSpace(text)
 
@@ -55,18 +60,18 @@ func Prepare(text, ext string) (string, bool) { //nolint:cyclop
 		return prepareFR(text), true
 	case "ru", "su", "xn--p1ai":
 		return prepareRU(text), true
-	case "fi":
+	case "fi", "ax":
 		return prepareFI(text), true
 	case "jp":
 		return prepareJP(text), true
 	case "uk":
 		return prepareUK(text), true
 	case "kr":
-		return prepareKR(text), true
+		return prepareKR(text, o.language), true
 	case "nz":
 		return prepareNZ(text), true
-	case "tk":
-		return prepareTK(text), true
+	case "tk", "ml", "ga", "cf", "gq":
+		return prepareFreenom(text), true
 	case "nl":
 		return prepareNL(text), true
 	case "eu":
@@ -82,7 +87,7 @@ func Prepare(text, ext string) (string, bool) { //nolint:cyclop
 	case "ee":
 		return prepareEE(text), true
 	case "cn", "xn--fiqs8s", "xn--fiqz9s":
-		return prepareCN(text), true
+		return prepareCN(text, o.language), true
 	case "pl":
 		return preparePL(text), true
 	case "dk":
@@ -93,7 +98,29 @@ func Prepare(text, ext string) (string, bool) { //nolint:cyclop
 		return prepareUA(text), true
 	case "at":
 		return prepareAT(text), true
+	case "gov", "mil":
+		return prepareGOV(text), true
+	case "ph":
+		return preparePH(text), true
+	case "to":
+		return prepareTO(text), true
+	case "cat":
+		return prepareCAT(text), true
+	case "fo":
+		return prepareFO(text), true
+	case "ad":
+		return prepareAD(text), true
+	case "mc":
+		return prepareMC(text), true
+	case "sm":
+		return prepareSM(text), true
+	case "tel":
+		return prepareTEL(text), true
 	default:
+		// the RIPE style blocks are resolved generically when there is no specific handler
+		if isRIPEBlockWhois(text) {
+			return prepareRIPE(text), true
+		}
 		return text, false
 	}
 }
@@ -110,7 +137,7 @@ func prepareTLD(text string) string {
 			continue
 		}
 		if strings.Contains(v, ":") {
-			vs := strings.Split(v, ":")
+			vs := strings.SplitN(v, ":", 2)
 			if strings.TrimSpace(vs[0]) == "organisation" {
 				if token == "" {
 					token = "registrant"
@@ -130,6 +157,24 @@ func prepareTLD(text string) string {
 	return result
 }
 
+var prepareEDUEmailRx = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+var prepareEDUPhoneRx = regexp.MustCompile(`^\+?[0-9][0-9\.\-\s\(\)]{6,}((x|ext\.?)\s*[0-9]+)?$`)
+
+// prepareEDUField returns the contact field detected by the form of value, empty if not detected
+func prepareEDUField(v string) string {
+	switch {
+	case prepareEDUEmailRx.MatchString(v):
+		return "Email"
+	case prepareEDUPhoneRx.MatchString(v):
+		return "Phone"
+	case v[0] >= '0' && v[0] <= '9':
+		// street number
+		return "Address"
+	default:
+		return ""
+	}
+}
+
 // prepareEDU do prepare the .edu domain
 func prepareEDU(text string) string {
 	tokens := map[string][]string{
@@ -174,10 +219,13 @@ func prepareEDU(text string) string {
 			if token == "" {
 				result += "\n" + v
 			} else {
-				// address ending now jump to phone
-				if tokens[token][index] == "Address" && strings.HasPrefix(v, "+") {
-					found := xslice.Index(tokens[token], "Phone")
-					if found != -1 {
+				if index > len(tokens[token])-1 {
+					continue
+				}
+				// the lines are positional but some may be missing, so jump ahead to the field detected by value
+				if field := prepareEDUField(v); field != "" {
+					found := xslice.Index(tokens[token], field)
+					if found > index {
 						index = found
 					}
 				}
@@ -192,35 +240,65 @@ func prepareEDU(text string) string {
 	return result
 }
 
-// prepareINT do prepare the .int domain
+// prepareINT do prepare the .int domain, the organisation block is of the registrant,
+// the address lines of a block are joined into one, which may be continued without the key
 func prepareINT(text string) string {
+	topKeys := []string{"domain", "nserver", "ds-rdata", "whois", "status", "remarks", "created", "changed", "source"}
+
 	token := ""
+	address := []string{}
 	result := ""
 
+	flush := func() {
+		if len(address) > 0 {
+			result += fmt.Sprintf("\n%s address: %s", token, strings.Join(address, ", "))
+			address = address[:0]
+		}
+	}
+
 	for _, v := range strings.Split(text, "\n") {
 		v = strings.TrimSpace(v)
 		if v == "" {
+			flush()
 			token = ""
 			continue
 		}
-		if strings.Contains(v, ":") {
-			vs := strings.Split(v, ":")
-			if strings.TrimSpace(vs[0]) == "organisation" {
-				if token == "" {
-					token = "registrant"
-				}
-			}
-			if strings.TrimSpace(vs[0]) == "contact" {
-				token = strings.TrimSpace(vs[1])
+		if !strings.Contains(v, ":") {
+			if len(address) > 0 {
+				address = append(address, v)
 			} else {
-				if token != "" {
-					v = fmt.Sprintf("%s %s", token, v)
-				}
+				result += "\n" + v
+			}
+			continue
+		}
+
+		vs := strings.SplitN(v, ":", 2)
+		key, value := strings.TrimSpace(vs[0]), strings.TrimSpace(vs[1])
+		if key == "address" && token != "" {
+			if value != "" {
+				address = append(address, value)
 			}
+			continue
+		}
+
+		flush()
+		switch {
+		case key == "contact":
+			token = value
+		case key == "organisation" && token == "":
+			token = "registrant"
+		case assert.IsContains(topKeys, key):
+			// the block may end without a blank line
+			token = ""
+		}
+		if token != "" && key != "contact" {
+			v = fmt.Sprintf("%s %s", token, v)
 		}
 		result += "\n" + v
 	}
 
+	flush()
+
 	return result
 }
 
@@ -351,10 +429,21 @@ func prepareHK(text string) string {
 	addressToken := false
 	text = strings.Replace(text, "\n\n", "\n", -1)
 
+	// the given name and family name are joined into one name, "." is the placeholder of empty one
+	givenName, familyName := "", ""
+	flushName := func(result string) string {
+		if name := strings.TrimSpace(givenName + " " + familyName); name != "" {
+			result += "\n" + strings.TrimSpace(token+" Given name: ") + " " + name
+		}
+		givenName, familyName = "", ""
+		return result
+	}
+
 	result := ""
 	for _, v := range strings.Split(text, "\n") {
 		v = strings.TrimSpace(v)
 		if v == "" {
+			result = flushName(result)
 			token = ""
 			continue
 		}
@@ -366,6 +455,18 @@ func prepareHK(text string) string {
 				field = strings.Split(field, "(")[0]
 				v = fmt.Sprintf("%s: %s", field, vs[1])
 			}
+			if strings.EqualFold(field, "Given name") || strings.EqualFold(field, "Family name") {
+				name := strings.TrimSpace(vs[1])
+				if name == "." {
+					name = ""
+				}
+				if strings.EqualFold(field, "Given name") {
+					givenName = name
+				} else {
+					familyName = name
+				}
+				continue
+			}
 			addressToken = field == "Address"
 			if field == "Registrar Contact Information" {
 				m := prepareHKEmailRx.FindStringSubmatch(vs[1])
@@ -380,19 +481,13 @@ func prepareHK(text string) string {
 					v = strings.TrimSpace(v)
 				}
 			}
-			if field == "Family name" {
-				vv := strings.TrimSpace(vs[1])
-				if vv != "" && vv != "." {
-					result += " " + vv
-				}
-				continue
-			}
 		} else {
 			if addressToken {
 				result += ", " + v
 				continue
 			}
 		}
+		result = flushName(result)
 		if _, ok := tokens[v]; ok {
 			token = tokens[v]
 		} else {
@@ -403,11 +498,11 @@ func prepareHK(text string) string {
 		result += "\n" + v
 	}
 
+	result = flushName(result)
+
 	return result
 }
 
-var prepareTWEmailRx = regexp.MustCompile(`(.*)\s+([^\s]+@[^\s]+)`)
-
 // prepareTW do prepare the .tw domain
 func prepareTW(text string) string { //nolint:cyclop
 	tokens := map[string][]string{
@@ -474,7 +569,11 @@ func prepareTW(text string) string { //nolint:cyclop
 					} else if index == 1 {
 						// Organization two line, join it
 						result = strings.TrimSpace(result)
-						if !strings.HasSuffix(result, ":") {
+						last := result[strings.LastIndex(result, ":")+1:]
+						if strings.TrimSpace(last) != "" && isNonLatin(last) != isNonLatin(v) {
+							// Organization in both native and latin, keep them both
+							result += fmt.Sprintf("\n%s %s: %s", tokenName, tokens[token][index], v)
+						} else if !strings.HasSuffix(result, ":") {
 							result += ", " + v
 						} else {
 							result += " " + v
@@ -494,10 +593,9 @@ func prepareTW(text string) string { //nolint:cyclop
 				}
 				if strings.Contains(indexName, ",") {
 					ins := strings.Split(indexName, ",")
-					m := prepareTWEmailRx.FindStringSubmatch(v)
-					if len(m) == 3 {
-						result += fmt.Sprintf("\n%s %s: %s", tokenName, ins[0], strings.TrimSpace(m[1]))
-						result += fmt.Sprintf("\n%s %s: %s", tokenName, ins[1], strings.TrimSpace(m[2]))
+					if name, _, email := splitContactLine(v); name != "" && email != "" {
+						result += fmt.Sprintf("\n%s %s: %s", tokenName, ins[0], name)
+						result += fmt.Sprintf("\n%s %s: %s", tokenName, ins[1], email)
 					} else {
 						result += fmt.Sprintf("\n%s %s: %s", tokenName, ins[0], strings.TrimSpace(v))
 					}
@@ -548,6 +646,9 @@ func prepareCH(text string) string {
 				lastTokenIndex++
 				v = strings.TrimSpace(v[len(phoneMark)-1:])
 			}
+			if lastTokenIndex > len(tokens[lastToken])-1 {
+				continue
+			}
 			result += fmt.Sprintf("\n%s: %s", tokens[lastToken][lastTokenIndex], v)
 			if tokens[lastToken][lastTokenIndex] != "Registrar street" {
 				lastTokenIndex++
@@ -582,9 +683,12 @@ func prepareIT(text string) string {
 		if assert.IsContains(topTokens, v) {
 			topToken = v + " "
 			subToken = ""
+		} else if topToken == "Nameservers " {
+			// the name server may be followed by the glue ips, the IPv6 has colons but no key
+			result += fmt.Sprintf("\n%s: %s", topToken, v)
 		} else {
 			if v[0] != '*' && strings.Contains(v, ":") {
-				vs := strings.Split(v, ":")
+				vs := strings.SplitN(v, ":", 2)
 				subToken = vs[0]
 			} else {
 				if subToken != "" {
@@ -615,7 +719,16 @@ func prepareFR(text string) string {
 		"tech-c":   "tech",
 	}
 
-	token := ""
+	// anonymized handles like ANO00-FRNIC may be shared by roles with only one block
+	hdlBlocks := map[string]int{}
+	for _, v := range strings.Split(text, "\n") {
+		vs := strings.SplitN(v, ":", 2)
+		if len(vs) == 2 && strings.TrimSpace(vs[0]) == hdlToken {
+			hdlBlocks[strings.TrimSpace(vs[1])]++
+		}
+	}
+
+	prefixes := []string{}
 	newBlock := false
 	hdls := map[string]string{}
 
@@ -627,32 +740,46 @@ func prepareFR(text string) string {
 			continue
 		}
 
-		vs := strings.Split(v, ":")
-		if newBlock && strings.TrimSpace(vs[0]) == regToken {
-			token = regToken + " "
-			v = fmt.Sprintf("name: %s", strings.TrimSpace(vs[1]))
+		key, value := v, ""
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key, value = strings.TrimSpace(vs[0]), strings.TrimSpace(vs[1])
+		}
+
+		if newBlock && key == regToken {
+			prefixes = []string{regToken + " "}
+			v = fmt.Sprintf("name: %s", value)
 		}
 
 		newBlock = false
-		if t, ok := tokens[strings.TrimSpace(vs[0])]; ok {
-			hdls[t] = strings.TrimSpace(vs[1])
+		if t, ok := tokens[key]; ok {
+			hdls[t] = value
 		}
 
-		if strings.TrimSpace(vs[0]) == dsToken && strings.TrimSpace(vs[1]) != "" {
+		if key == dsToken && value != "" {
 			v += "\nDNSSEC: signed"
 		}
 
-		if strings.TrimSpace(vs[0]) == hdlToken {
+		if key == hdlToken {
+			prefixes = []string{}
 			for _, kk := range keys(hdls) {
-				if strings.TrimSpace(vs[1]) == hdls[kk] {
-					token = kk + " "
+				if value == hdls[kk] {
+					prefixes = append(prefixes, kk+" ")
 					delete(hdls, kk)
-					break
+					if hdlBlocks[value] > 1 {
+						break
+					}
 				}
 			}
+			hdlBlocks[value]--
 		}
 
-		result += fmt.Sprintf("\n%s%s", token, v)
+		if len(prefixes) == 0 {
+			result += "\n" + v
+		}
+
+		for _, prefix := range prefixes {
+			result += fmt.Sprintf("\n%s%s", prefix, v)
+		}
 	}
 
 	return result
@@ -675,7 +802,7 @@ func prepareRU(text string) string {
 		if !strings.Contains(v, ":") {
 			continue
 		}
-		vs := strings.Split(v, ":")
+		vs := strings.SplitN(v, ":", 2)
 		if vv, ok := tokens[strings.TrimSpace(vs[0])]; ok {
 			v = fmt.Sprintf("%s: %s", vv, vs[1])
 		} else if vs[0] == "nserver" {
@@ -689,12 +816,15 @@ func prepareRU(text string) string {
 
 var prepareJPreplacerRx = regexp.MustCompile(`\n(?:\w+\.\s)?\[(.+?)\][\ ]*(.+?)?`)
 
+var prepareJPStateRx = regexp.MustCompile(`^(.+?)\s*\((\d{4}/\d{2}/\d{2})\)$`)
+
 // prepareJP do prepare the .jp domain
 func prepareJP(text string) string {
 	text = prepareJPreplacerRx.ReplaceAllString(text, "\n$1: $2")
 
 	adminToken := "Contact Information"
 	addressToken := "Postal Address"
+	nameServerToken := "Name Server"
 
 	token := ""
 	prefixToken := ""
@@ -711,8 +841,19 @@ func prepareJP(text string) string {
 			if token == adminToken {
 				prefixToken = "admin "
 			}
-			if strings.ToLower(token) == "registrant" {
+			switch strings.ToLower(token) {
+			case "registrant":
 				v = fmt.Sprintf("registrant name: %s", vs[1])
+			case "signing key":
+				// the DS record of signed domain, it is empty if unsigned
+				if strings.TrimSpace(vs[1]) != "" {
+					v = "DNSSEC: signed"
+				}
+			case "state":
+				// the state of attribute type domain has the expiration date, like "Connected (2024/03/31)"
+				if m := prepareJPStateRx.FindStringSubmatch(strings.TrimSpace(vs[1])); m != nil {
+					v = fmt.Sprintf("State: %s\nExpires on: %s", m[1], m[2])
+				}
 			}
 			v = prepareSecondLevelJP(v, token, vs[1])
 		} else {
@@ -720,6 +861,10 @@ func prepareJP(text string) string {
 				result += ", " + v
 				continue
 			}
+			// the name servers may follow the key one per line without it
+			if token == nameServerToken {
+				v = fmt.Sprintf("%s: %s", nameServerToken, v)
+			}
 		}
 		result += "\n" + prefixToken + v
 	}
@@ -774,9 +919,31 @@ func prepareUK(text string) string {
 	return result
 }
 
-// prepareKR do prepare the .kr domain
-func prepareKR(text string) string {
+// prepareKRKoreanKeys is the english keys of the .kr korean block
+var prepareKRKoreanKeys = map[string]string{
+	"도메인이름":      "Domain Name",
+	"등록인":        "Registrant",
+	"등록인 주소":     "Registrant Address",
+	"등록인 우편번호":   "Registrant Zip Code",
+	"책임자":        "Administrative Contact(AC)",
+	"책임자 전자우편":   "AC E-Mail",
+	"책임자 전화번호":   "AC Phone Number",
+	"등록일":        "Registered Date",
+	"최근 정보 변경일":  "Last Updated Date",
+	"사용 종료일":     "Expiration Date",
+	"정보공개여부":     "Publishes",
+	"등록대행자":      "Authorized Agency",
+	"1차 네임서버 정보": "Primary Name Server",
+	"2차 네임서버 정보": "Secondary Name Server",
+	"호스트이름":      "Host Name",
+	"IP 주소":      "IP Address",
+}
+
+// prepareKR do prepare the .kr domain, the english block is used unless the local language is preferred,
+// the keys of korean block are mapped to the english ones, so the korean only response is parsed as well
+func prepareKR(text, language string) string {
 	english := "# ENGLISH"
+	korean := "# KOREAN(UTF8)"
 	tokens := map[string]string{
 		"Administrative Contact(AC)": "Administrative Contact Name",
 		"AC E-Mail":                  "Administrative Contact E-Mail",
@@ -785,8 +952,14 @@ func prepareKR(text string) string {
 		"Registrant":                 "Registrant Name",
 	}
 
-	pos := strings.Index(text, english)
-	if pos != -1 {
+	if language == LanguageLocal {
+		if pos := strings.Index(text, korean); pos != -1 {
+			text = text[pos+len(korean):]
+			if pos := strings.Index(text, english); pos != -1 {
+				text = text[:pos]
+			}
+		}
+	} else if pos := strings.Index(text, english); pos != -1 {
 		text = text[pos+len(english):]
 	}
 
@@ -799,10 +972,20 @@ func prepareKR(text string) string {
 		if v[0] == '\'' || v[0] == '-' {
 			continue
 		}
+		if vv, ok := prepareKRKoreanKeys[v]; ok {
+			v = vv
+		}
 		if strings.Contains(v, ":") {
 			vs := strings.SplitN(v, ":", 2)
-			if vv, ok := tokens[strings.TrimSpace(vs[0])]; ok {
-				v = fmt.Sprintf("%s: %s", vv, vs[1])
+			key := strings.TrimSpace(vs[0])
+			if vv, ok := prepareKRKoreanKeys[key]; ok {
+				key = vv
+			}
+			if vv, ok := tokens[key]; ok {
+				key = vv
+			}
+			if key != strings.TrimSpace(vs[0]) {
+				v = fmt.Sprintf("%s: %s", key, vs[1])
 			}
 		}
 		result += "\n" + v
@@ -811,7 +994,14 @@ func prepareKR(text string) string {
 	return result
 }
 
-// prepareNZ do prepare the .nz domain
+// prepareNZQueryStatus is the domain status of .nz query_status code, the 220 Available is not found
+var prepareNZQueryStatus = map[string]string{
+	"200": "registered",
+	"210": "pendingRelease",
+}
+
+// prepareNZ do prepare the .nz domain, the query_status code is converted to the domain status,
+// the text following an unknown code is kept as the status
 func prepareNZ(text string) string {
 	result := ""
 
@@ -819,9 +1009,20 @@ func prepareNZ(text string) string {
 		v = strings.TrimSpace(v)
 		if strings.Contains(v, ":") {
 			vs := strings.SplitN(v, ":", 2)
-			if strings.HasPrefix(strings.TrimSpace(vs[0]), "ns_name_") {
+			key := strings.TrimSpace(vs[0])
+			if strings.HasPrefix(key, "ns_name_") {
 				v = fmt.Sprintf("name server: %s", vs[1])
 			}
+			if key == "query_status" {
+				code, status := strings.TrimSpace(vs[1]), ""
+				if i := strings.IndexByte(code, ' '); i > 0 {
+					code, status = code[:i], strings.TrimSpace(code[i+1:])
+				}
+				if vv, ok := prepareNZQueryStatus[code]; ok {
+					status = vv
+				}
+				v = fmt.Sprintf("query_status: %s", status)
+			}
 		}
 		result += "\n" + v
 	}
@@ -829,8 +1030,8 @@ func prepareNZ(text string) string {
 	return result
 }
 
-// prepareTK do prepare the .tk domain
-func prepareTK(text string) string {
+// prepareFreenom do prepare the Freenom operated .tk, .ml, .ga, .cf and .gq domain
+func prepareFreenom(text string) string {
 	tokens := map[string]string{
 		"Domain name:":        "Domain",
 		"Domain Nameservers:": "Nameservers",
@@ -839,6 +1040,7 @@ func prepareTK(text string) string {
 		"Billing contact:":    "Billing",
 		"Tech contact:":       "Technical",
 		"Organisation:":       "Registrant",
+		"Organization:":       "Registrant",
 	}
 
 	fields := map[string][]string{
@@ -855,24 +1057,35 @@ func prepareTK(text string) string {
 	token := ""
 	result := ""
 	index := 0
+	lines := 0
 
 	for _, v := range strings.Split(text, "\n") {
 		v = strings.TrimSpace(v)
 		if v == "" {
-			token = ""
+			// a blank line right after the section title does not end the section
+			if lines > 0 {
+				token = ""
+			}
 			continue
 		}
 		if _, ok := tokens[v]; ok {
-			token = tokens[v]
-			continue
+			// an empty organization line within a contact section is not the legacy title
+			if token == "" || !strings.HasPrefix(v, "Organi") {
+				token = tokens[v]
+				index = 0
+				lines = 0
+				continue
+			}
 		}
+		lines++
 		if token == "Domain" && strings.Contains(v, " is ") {
-			vv := strings.Split(v, " is ")
+			vv := strings.SplitN(v, " is ", 2)
 			v = fmt.Sprintf("Name: %s\nStatus: %s", vv[0], vv[1])
-		} else if token == "Registrant" && !strings.Contains(v, ":") {
+		} else if token == "Registrant" && !strings.Contains(v, ":") && index < len(fields[token]) {
 			v = fmt.Sprintf("%s: %s", fields[token][index], v)
 			index++
 		}
+		v = prepareFreenomField(v)
 		if token != "" {
 			if !strings.Contains(v, ":") {
 				v = fmt.Sprintf("%s: %s", token, v)
@@ -886,6 +1099,33 @@ func prepareTK(text string) string {
 	return result
 }
 
+// prepareFreenomField returns the Freenom field line with the state as province
+// and the labels like "abuse: abuse@freenom.com" removed from emails
+func prepareFreenomField(v string) string {
+	vs := strings.SplitN(v, ":", 2)
+	if len(vs) != 2 {
+		return v
+	}
+
+	key, value := strings.TrimSpace(vs[0]), strings.TrimSpace(vs[1])
+	switch key {
+	case "State":
+		key = "State/Province"
+	case "E-mail", "Email":
+		emails := []string{}
+		for _, e := range strings.Split(value, ",") {
+			if es := strings.Fields(e); len(es) > 0 {
+				emails = append(emails, es[len(es)-1])
+			}
+		}
+		value = strings.Join(emails, ", ")
+	default:
+		return v
+	}
+
+	return fmt.Sprintf("%s: %s", key, value)
+}
+
 // prepareNL do prepare the .nl domain
 func prepareNL(text string) string {
 	tokens := map[string][]string{
@@ -924,6 +1164,9 @@ func prepareNL(text string) string {
 			if token == "" {
 				result += "\n" + v
 			} else {
+				if index > len(tokens[token])-1 {
+					continue
+				}
 				result += fmt.Sprintf("\n%s %s: %s", token[:len(token)-1], tokens[token][index], v)
 				index++
 			}
@@ -1085,12 +1328,15 @@ func prepareIR(text string) string {
 	return result
 }
 
-// prepareFI do prepare the .fi domain
+// prepareFI do prepare the .fi and .ax domain, which share the dotted keys and contact sections
 func prepareFI(text string) string {
 	tokens := map[string]string{
-		"Holder":    "Registrant",
-		"Registrar": "Registrar",
-		"Tech":      "Technical",
+		"Holder":      "Registrant",
+		"Registrant":  "Registrant",
+		"Nameservers": "",
+		"DNSSEC":      "",
+		"Registrar":   "Registrar",
+		"Tech":        "Technical",
 	}
 
 	token := ""
@@ -1165,7 +1411,15 @@ func prepareEE(text string) string {
 		"Registrant:":             "Registrant",
 		"Administrative contact:": "Administrative",
 		"Technical contact:":      "Technical",
-		"Name servers:":           "",
+		"Name servers:":           "Name servers",
+		"DNSSEC:":                 "DNSSEC",
+	}
+
+	// the domain dates are kept as is, the changed of other sections is not the domain updated date
+	domainTokens := map[string]string{
+		"changed":  "Updated Date",
+		"expire":   "Expiry Date",
+		"valid to": "Expiry Date",
 	}
 
 	token := ""
@@ -1181,6 +1435,30 @@ func prepareEE(text string) string {
 			token = t
 			continue
 		}
+
+		key, value := v, ""
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key, value = strings.TrimSpace(vs[0]), strings.TrimSpace(vs[1])
+		}
+
+		switch token {
+		case "Domain":
+			if t, ok := domainTokens[key]; ok {
+				result += fmt.Sprintf("\n%s: %s", t, value)
+				continue
+			}
+		case "Name servers":
+			if key != "changed" {
+				result += "\n" + v
+			}
+			continue
+		case "DNSSEC":
+			if key == "dnskey" && value != "" && !strings.HasSuffix(result, "\nDNSSEC: signed") {
+				result += "\nDNSSEC: signed"
+			}
+			continue
+		}
+
 		v = fmt.Sprintf("%s %s", token, v)
 		result += "\n" + strings.TrimSpace(v)
 	}
@@ -1188,10 +1466,24 @@ func prepareEE(text string) string {
 	return result
 }
 
-// prepareCN do prepare the .cn domain
-func prepareCN(text string) string {
+// prepareCN do prepare the .cn domain, the bilingual registrant organization is kept both
+// with the romanized one preferred, the romanized one is dropped if the local language is preferred
+func prepareCN(text, language string) string {
 	var result string
 
+	isOrganization := func(key string) bool {
+		return clearKeyName(key) == "registrant organization"
+	}
+
+	hasLocal := false
+	if language == LanguageLocal {
+		for _, v := range strings.Split(text, "\n") {
+			if vs := strings.SplitN(v, ":", 2); len(vs) == 2 && isOrganization(vs[0]) && isNonLatin(vs[1]) {
+				hasLocal = true
+			}
+		}
+	}
+
 	for _, v := range strings.Split(text, "\n") {
 		v = strings.TrimSpace(v)
 		if strings.Contains(v, ":") {
@@ -1199,6 +1491,9 @@ func prepareCN(text string) string {
 			if strings.ToLower(strings.TrimSpace(vs[0])) == "registrant" {
 				vs[0] = "registrant name"
 			}
+			if hasLocal && isOrganization(vs[0]) && !isNonLatin(vs[1]) {
+				continue
+			}
 			v = fmt.Sprintf("%s: %s", vs[0], vs[1])
 		}
 		result += "\n" + v
@@ -1224,14 +1519,20 @@ func preparePL(text string) string {
 			if strings.TrimSpace(v) == "" {
 				special = ""
 			} else {
-				switch registrarLine {
-				case 0:
+				lower := strings.ToLower(strings.TrimSpace(v))
+				switch {
+				case registrarLine == 0:
 					// always name
 					result += fmt.Sprintf("\nregistrar name: %s", strings.TrimSpace(v))
-				case 1:
+				case registrarLine == 1:
 					// always street address
 					result += fmt.Sprintf("\nregistrar street: %s", strings.TrimSpace(v))
-				case 2:
+				case strings.HasPrefix(lower, "tel"):
+					// phone and fax may be labeled, like "tel.+357.22761649" and "fax:+357.22767543"
+					result += fmt.Sprintf("\nregistrar phone: %s", strings.TrimLeft(strings.TrimSpace(v)[3:], ".: "))
+				case strings.HasPrefix(lower, "fax"):
+					result += fmt.Sprintf("\nregistrar fax: %s", strings.TrimLeft(strings.TrimSpace(v)[3:], ".: "))
+				case registrarLine == 2:
 					// postal code, city, state, sometimes country in an undefined format
 					// there's no way we can reliably unpack that
 					registrarLine++
@@ -1340,7 +1641,7 @@ func prepareUA(text string) string {
 			token = v
 			continue
 		}
-		if token != "" && v != "" && !strings.HasPrefix(v, "%") {
+		if token != "" && strings.Contains(v, ":") && !strings.HasPrefix(v, "%") {
 			vs := strings.SplitN(v, ":", 2)
 			vs[0] = strings.TrimSuffix(strings.TrimSpace(vs[0]), "-loc")
 			if vs[0] == "registrar" {
@@ -1436,3 +1737,493 @@ func prepareAT(text string) string {
 
 	return result
 }
+
+// prepareGOV do prepare the .gov domain, which has the registrant organization and location only
+func prepareGOV(text string) string {
+	tokens := map[string]string{
+		"organization": "Registrant Organization",
+		"city":         "Registrant City",
+		"state":        "Registrant State/Province",
+		"country":      "Registrant Country",
+	}
+
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "%") {
+			continue
+		}
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			if t, ok := tokens[strings.ToLower(strings.TrimSpace(vs[0]))]; ok {
+				v = fmt.Sprintf("%s: %s", t, strings.TrimSpace(vs[1]))
+			}
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
+
+// preparePH do prepare the .ph domain
+func preparePH(text string) string {
+	tokens := map[string]string{
+		"province":     "State/Province",
+		"nameservers":  "Name Server",
+		"name servers": "Name Server",
+	}
+
+	token := ""
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			token = ""
+			continue
+		}
+		if role := searchContactSection(v); role != "" {
+			token = strings.ToUpper(role[:1]) + role[1:]
+			continue
+		}
+		if t, ok := tokens[strings.ToLower(strings.TrimSuffix(v, ":"))]; ok {
+			token = t
+			continue
+		}
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key := strings.TrimSpace(vs[0])
+			if t, ok := tokens[strings.ToLower(key)]; ok {
+				key = t
+			}
+			v = fmt.Sprintf("%s: %s", key, strings.TrimSpace(vs[1]))
+			if token != "" {
+				v = fmt.Sprintf("%s %s", token, v)
+			}
+		} else if token != "" {
+			v = fmt.Sprintf("%s: %s", token, v)
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
+
+// prepareTO do prepare the .to domain, which has the lines of name and name server,
+// the availability of domain or the bare domain name only
+func prepareTO(text string) string {
+	domain := ""
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "Tonic whoisd") {
+			continue
+		}
+		if strings.Contains(v, ":") {
+			result += "\n" + v
+			continue
+		}
+
+		vs := strings.Fields(v)
+		lower := strings.ToLower(v)
+		name, server := vs[0], ""
+		switch {
+		case strings.HasSuffix(lower, " is available"):
+			result += fmt.Sprintf("\nNo match for %s.", name)
+			continue
+		case strings.HasSuffix(lower, " is not available"):
+		case len(vs) == 1:
+		case len(vs) == 2:
+			server = vs[1]
+		default:
+			result += "\n" + v
+			continue
+		}
+
+		if !strings.Contains(name, ".") {
+			name += ".to"
+		}
+		if name != domain {
+			domain = name
+			result += fmt.Sprintf("\nDomain Name: %s", name)
+		}
+		if server != "" {
+			result += fmt.Sprintf("\nName Server: %s", server)
+		}
+	}
+
+	return strings.TrimSpace(result)
+}
+
+// prepareCAT do prepare the .cat domain, the puntCAT eligibility data of intended use and language,
+// which may be in Catalan or wrapped over lines, is kept for the extension fields
+func prepareCAT(text string) string {
+	tokens := map[string]string{
+		"intended use":    "Intended Use",
+		"ús previst":      "Intended Use",
+		"domain language": "Domain Language",
+		"idioma":          "Domain Language",
+		"eligibility":     "Eligibility",
+		"elegibilitat":    "Eligibility",
+	}
+
+	token := ""
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			token = ""
+			continue
+		}
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			token = ""
+			if t, ok := tokens[strings.ToLower(strings.TrimSpace(vs[0]))]; ok {
+				token = t
+				v = fmt.Sprintf("%s: %s", t, strings.TrimSpace(vs[1]))
+			}
+		} else if token != "" {
+			result += " " + v
+			continue
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
+
+// prepareFO do prepare the .fo domain, the RIPE style contact blocks are resolved to the roles by handle
+func prepareFO(text string) string {
+	tokens := map[string]string{
+		"registrant": "Registrant",
+		"admin-c":    "Admin",
+		"tech-c":     "Tech",
+	}
+
+	hdls := map[string][]string{}
+	for _, v := range strings.Split(text, "\n") {
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			if t, ok := tokens[strings.TrimSpace(vs[0])]; ok {
+				hdl := strings.TrimSpace(vs[1])
+				hdls[hdl] = append(hdls[hdl], t)
+			}
+		}
+	}
+
+	prefixes := []string{}
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "%") {
+			prefixes = []string{}
+			continue
+		}
+
+		key, value := v, ""
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key, value = strings.TrimSpace(vs[0]), strings.TrimSpace(vs[1])
+		}
+
+		if t, ok := tokens[key]; ok {
+			v = fmt.Sprintf("%s ID: %s", t, value)
+		}
+
+		if key == "contact" {
+			prefixes = hdls[value]
+			continue
+		}
+
+		if len(prefixes) == 0 {
+			result += "\n" + v
+		}
+
+		for _, prefix := range prefixes {
+			result += fmt.Sprintf("\n%s %s", prefix, v)
+		}
+	}
+
+	return result
+}
+
+// prepareAD do prepare the .ad domain, the owner fields are the registrant and name servers are a block
+func prepareAD(text string) string {
+	tokens := map[string]string{
+		"owner":         "Registrant Organization",
+		"owner address": "Registrant Street",
+		"owner country": "Registrant Country",
+	}
+
+	inNameServers := false
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			inNameServers = false
+			continue
+		}
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key, value := strings.ToLower(strings.TrimSpace(vs[0])), strings.TrimSpace(vs[1])
+			inNameServers = key == "name servers" && value == ""
+			if inNameServers {
+				continue
+			}
+			if t, ok := tokens[key]; ok {
+				v = fmt.Sprintf("%s: %s", t, value)
+			}
+		} else if inNameServers {
+			v = fmt.Sprintf("Name Server: %s", v)
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
+
+// prepareMC do prepare the .mc domain, the holder fields are the registrant and name servers are numbered
+func prepareMC(text string) string {
+	tokens := map[string]string{
+		"holder":         "Registrant Organization",
+		"holder address": "Registrant Street",
+		"holder country": "Registrant Country",
+		"admin contact":  "Admin Name",
+	}
+
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+			key, value := strings.ToLower(strings.TrimSpace(vs[0])), strings.TrimSpace(vs[1])
+			if t, ok := tokens[key]; ok {
+				v = fmt.Sprintf("%s: %s", t, value)
+			} else if strings.HasPrefix(key, "name server ") {
+				v = fmt.Sprintf("Name Server: %s", value)
+			}
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
+
+// prepareSM do prepare the .sm domain, the contacts and name servers are blocks of unlabeled lines,
+// the contact lines are taken by position, with email and country code lines recognized by the value
+func prepareSM(text string) string {
+	tokens := map[string][]string{
+		"Owner":             {"Registrant Organization", "Registrant Street"},
+		"Technical Contact": {"Technical Name", "Technical Organization", "Technical Street"},
+		"DNS Servers":       {"Name Server"},
+	}
+
+	token := ""
+	index := 0
+	result := ""
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			token = ""
+			continue
+		}
+		if _, ok := tokens[strings.TrimSuffix(v, ":")]; ok && strings.HasSuffix(v, ":") {
+			token = strings.TrimSuffix(v, ":")
+			index = 0
+			continue
+		}
+		if token == "" || strings.Contains(v, ": ") {
+			result += "\n" + v
+			continue
+		}
+
+		names := tokens[token]
+		name := names[len(names)-1]
+		if index < len(names) {
+			name = names[index]
+		}
+		index++
+
+		prefix := strings.Fields(names[0])[0]
+		switch {
+		case token == "DNS Servers":
+		case strings.Contains(v, "@"):
+			name = prefix + " Email"
+		case len(v) == 2 && strings.ToUpper(v) == v:
+			name = prefix + " Country"
+		}
+		result += fmt.Sprintf("\n%s: %s", name, v)
+	}
+
+	return result
+}
+
+// isRIPEBlockWhois returns if whois data is RIPE style blocks, the contact blocks has nic-hdl
+// and the domain block references them by admin-c or tech-c
+func isRIPEBlockWhois(text string) bool {
+	hasHdl, hasRef := false, false
+	for _, v := range strings.Split(text, "\n") {
+		vs := strings.SplitN(v, ":", 2)
+		if len(vs) != 2 {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(vs[0])) {
+		case "nic-hdl":
+			hasHdl = true
+		case "admin-c", "tech-c":
+			hasRef = true
+		}
+	}
+
+	return hasHdl && hasRef
+}
+
+// prepareRIPE do prepare the RIPE style blocks of extension without specific handler,
+// the contact blocks are resolved to the roles by nic-hdl, the object name of person or role is the contact name,
+// the org handles are resolved to the org-name of organisation blocks
+func prepareRIPE(text string) string {
+	tokens := map[string]string{
+		"registrant": "Registrant",
+		"holder":     "Registrant",
+		"holder-c":   "Registrant",
+		"owner-c":    "Registrant",
+		"admin-c":    "Admin",
+		"tech-c":     "Tech",
+		"billing-c":  "Billing",
+	}
+
+	blocks := [][]string{}
+	block := []string{}
+	for _, v := range strings.Split(stripWhoisComments(text), "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+			}
+			block = []string{}
+			continue
+		}
+		block = append(block, v)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+
+	hdls := map[string]bool{}
+	for _, block := range blocks {
+		for _, v := range block {
+			if vs := strings.SplitN(v, ":", 2); len(vs) == 2 && strings.ToLower(strings.TrimSpace(vs[0])) == "nic-hdl" {
+				hdls[strings.TrimSpace(vs[1])] = true
+			}
+		}
+	}
+
+	orgs := map[string]string{}
+	for _, block := range blocks {
+		if hdl := ripeOrgHandle(block); hdl != "" {
+			for _, v := range block {
+				if vs := strings.SplitN(v, ":", 2); len(vs) == 2 && strings.ToLower(strings.TrimSpace(vs[0])) == "org-name" {
+					orgs[hdl] = strings.TrimSpace(vs[1])
+				}
+			}
+		}
+	}
+
+	roles := map[string][]string{}
+	for _, block := range blocks {
+		for _, v := range block {
+			if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+				hdl := strings.TrimSpace(vs[1])
+				if t, ok := tokens[strings.ToLower(strings.TrimSpace(vs[0]))]; ok && hdls[hdl] &&
+					!assert.IsContains(roles[hdl], t) {
+					roles[hdl] = append(roles[hdl], t)
+				}
+			}
+		}
+	}
+
+	result := ""
+	for _, block := range blocks {
+		// the organisation block is resolved into the blocks referencing it
+		if hdl := ripeOrgHandle(block); hdl != "" && orgs[hdl] != "" {
+			continue
+		}
+
+		prefixes := []string{}
+		for _, v := range block {
+			if vs := strings.SplitN(v, ":", 2); len(vs) == 2 && strings.ToLower(strings.TrimSpace(vs[0])) == "nic-hdl" {
+				prefixes = roles[strings.TrimSpace(vs[1])]
+			}
+		}
+
+		for _, v := range block {
+			key, value := v, ""
+			if vs := strings.SplitN(v, ":", 2); len(vs) == 2 {
+				key, value = strings.ToLower(strings.TrimSpace(vs[0])), strings.TrimSpace(vs[1])
+			}
+
+			if len(prefixes) == 0 {
+				if t, ok := tokens[key]; ok && hdls[value] {
+					v = fmt.Sprintf("%s ID: %s", t, value)
+				}
+				if key == "org" && orgs[value] != "" {
+					v = fmt.Sprintf("Registrant Organization: %s", orgs[value])
+				}
+				result += "\n" + v
+				continue
+			}
+
+			if key == "person" || key == "role" {
+				v = fmt.Sprintf("name: %s", value)
+			}
+			if key == "org" && orgs[value] != "" {
+				v = fmt.Sprintf("organization: %s", orgs[value])
+			}
+			for _, prefix := range prefixes {
+				result += fmt.Sprintf("\n%s %s", prefix, v)
+			}
+		}
+		result += "\n"
+	}
+
+	return strings.TrimSpace(result)
+}
+
+// ripeOrgHandle returns the handle of RIPE style organisation block, empty if it is not
+func ripeOrgHandle(block []string) string {
+	if len(block) == 0 {
+		return ""
+	}
+
+	vs := strings.SplitN(block[0], ":", 2)
+	if len(vs) != 2 {
+		return ""
+	}
+
+	switch strings.ToLower(strings.TrimSpace(vs[0])) {
+	case "organisation", "organization":
+		return strings.TrimSpace(vs[1])
+	}
+
+	return ""
+}
+
+// prepareTEL do prepare the .tel domain, the NAPTR records listed under the header are keyed one per line
+func prepareTEL(text string) string {
+	inNAPTR := false
+	result := ""
+
+	for _, v := range strings.Split(text, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			inNAPTR = false
+			result += "\n"
+			continue
+		}
+		if strings.EqualFold(v, "NAPTR Records:") {
+			inNAPTR = true
+			continue
+		}
+		// the NAPTR record has colons in the services and regexp but no key
+		if inNAPTR && !strings.Contains(v, ": ") {
+			v = "NAPTR Record: " + v
+		} else {
+			inNAPTR = false
+		}
+		result += "\n" + v
+	}
+
+	return result
+}
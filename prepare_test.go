@@ -70,3 +70,136 @@ func TestPrepare(t *testing.T) {
 		}
 	}
 }
+
+func TestPrepareMalformedLines(t *testing.T) {
+	lines := []string{
+		":",
+		"::",
+		"nic-hdl:",
+		"contact:",
+		"person:",
+		"registrar:",
+		"key: ",
+		"no colon",
+		"+",
+		"*",
+	}
+
+	tests := []struct {
+		extension string
+		header    string
+	}{
+		{"", "contact: administrative"},
+		{"edu", "Registrant:"},
+		{"int", "contact: administrative"},
+		{"ch", "Registrar"},
+		{"it", "Registrant"},
+		{"fr", "holder-c: ANO00-FRNIC"},
+		{"ru", "domain: example.ru"},
+		{"kr", "# ENGLISH"},
+		{"tk", "Owner contact:"},
+		{"nl", "Registrar:"},
+		{"ua", "% Technical Contacts:"},
+	}
+
+	for _, v := range tests {
+		text := v.header + "\n" + strings.Join(lines, "\n") + "\n" + strings.Repeat("\nvalue", 10)
+		_, prepared := Prepare(text, v.extension)
+		assert.True(t, prepared, v.extension)
+	}
+
+	// value with colon is kept as a whole
+	whoisPrepare, prepared := Prepare("person: Ivan Ivanov (https://example.ru)", "ru")
+	assert.True(t, prepared)
+	assert.Equal(t, strings.TrimSpace(whoisPrepare), "Registrant Name:  Ivan Ivanov (https://example.ru)")
+}
+
+func TestPrepareColonValues(t *testing.T) {
+	tests := []struct {
+		extension string
+		text      string
+		expect    string
+	}{
+		{"ru", "domain: example.ru\nnserver: ns1.example.ru. 2001:db8::53\nadmin-contact: https://example.ru/whois",
+			"nserver: ns1.example.ru. 2001:db8::53\nadmin-contact: https://example.ru/whois"},
+		{"it", "Domain: example.it\nRegistrar\n  Web: https://www.example.it\nNameservers\n  ns1.example.it 2001:db8::53",
+			"Registrar Web: https://www.example.it\nNameservers : ns1.example.it 2001:db8::53"},
+		{"ch", "Domain name\texample.ch\n\nName servers\nns1.example.ch\t2001:db8::53",
+			"Name servers: ns1.example.ch 2001:db8::53"},
+	}
+
+	for _, v := range tests {
+		whoisPrepare, prepared := Prepare(v.text, v.extension)
+		assert.True(t, prepared, v.extension)
+		assert.Contains(t, whoisPrepare, v.expect, v.extension)
+	}
+
+	// the parsed values are not truncated at the colon
+	for k, v := range map[string]string{
+		"ru_yandex.ru":       "2a02:6b8::1",
+		"it_example-glue.it": "2001:db8::2",
+		"ch_switch.ch":       "2001:620:0:ff::2f",
+	} {
+		whoisRaw, err := xfile.ReadText(noterrorDir + "/" + k)
+		assert.Nil(t, err)
+
+		whoisInfo, err := Parse(whoisRaw)
+		assert.Nil(t, err, k)
+
+		ips := []string{}
+		for _, vv := range whoisInfo.Domain.NameServerIPs {
+			ips = append(ips, vv...)
+		}
+		assert.True(t, assert.IsContains(ips, v), k)
+	}
+
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/it_example-glue.it")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrar.ReferralURL, "https://www.example-registrar.it")
+}
+
+func TestPrepareRIPE(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/mk_example.mk")
+	assert.Nil(t, err)
+	assert.True(t, isRIPEBlockWhois(whoisRaw))
+
+	// the unhandled extension falls back to the generic RIPE blocks
+	_, prepared := Prepare(whoisRaw, "mk")
+	assert.True(t, prepared)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.NameServers, []string{"ns1.example.mk", "ns2.example.mk"})
+	assert.Equal(t, whoisInfo.Registrant.ID, "EXMK1-MK")
+	assert.Equal(t, whoisInfo.Registrant.Name, "Example DOOEL")
+	assert.Equal(t, whoisInfo.Registrant.Street, "Partizanska 12, 1000 Skopje")
+	assert.Equal(t, whoisInfo.Registrant.Email, "info@example.mk")
+	assert.Equal(t, whoisInfo.Administrative.Name, "Petar Petrovski")
+	assert.Equal(t, whoisInfo.Technical.Name, "Example Hosting Team")
+	assert.Equal(t, whoisInfo.Technical.Email, "noc@example-hosting.mk")
+
+	// the handle shared by roles is resolved for each of them
+	whoisInfo, err = Parse(strings.Replace(whoisRaw, "tech-c:       EXMK3-MK", "tech-c:       EXMK2-MK", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Technical.Name, "Petar Petrovski")
+	assert.Equal(t, whoisInfo.Administrative.Name, "Petar Petrovski")
+
+	// handles without contact block are kept as is
+	_, prepared = Prepare("domain: example.mk\nadmin-c: EXMK2-MK\ntech-c: EXMK3-MK", "mk")
+	assert.False(t, prepared)
+
+	// the org handles are resolved to the organisation name
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/mk_example-org.mk")
+	assert.Nil(t, err)
+
+	whoisInfo, err = Parse(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Registrant.Name, "Marko Markovski")
+	assert.Equal(t, whoisInfo.Registrant.Organization, "Example Org DOOEL")
+	assert.Equal(t, whoisInfo.Administrative.Organization, "")
+	assert.Equal(t, whoisInfo.Technical.Name, "Example Org NOC")
+	assert.Equal(t, whoisInfo.Technical.Organization, "Example Org DOOEL")
+}
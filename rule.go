@@ -19,6 +19,11 @@
 
 package whoisparser
 
+import (
+	"strings"
+	"sync"
+)
+
 var (
 	// keyRule is the key rule mapper for parser
 	keyRule = map[string]string{
@@ -34,12 +39,26 @@ var (
 		"query status":                           "domain_status",
 		"dnssec":                                 "domain_dnssec",
 		"domain dnssec":                          "domain_dnssec",
+		"remarks":                                "domain_remarks",
+		"remark":                                 "domain_remarks",
+		"comment":                                "domain_remarks",
+		"comments":                               "domain_remarks",
+		"domain remarks":                         "domain_remarks",
+		"registrant remarks":                     "domain_remarks",
+		"registrant comment":                     "domain_remarks",
 		"registrar dnssec":                       "domain_dnssec",
 		"signing key":                            "domain_dnssec",
 		"domain signed":                          "domain_dnssec",
+		"reseller":                               "domain_reseller",
+		"reseller name":                          "domain_reseller",
+		"reseller email":                         "domain_reseller_email",
+		"reseller e mail":                        "domain_reseller_email",
+		"reseller url":                           "domain_reseller_url",
+		"reseller www":                           "domain_reseller_url",
+		"reseller website":                       "domain_reseller_url",
 		"whois":                                  "whois_server",
 		"whois server":                           "whois_server",
-		"registrar whois server":                 "whois_server",
+		"registrar whois server":                 "registrar_whois_server",
 		"dns":                                    "name_servers",
 		"nserver":                                "name_servers",
 		"name server":                            "name_servers",
@@ -85,6 +104,14 @@ var (
 		"domain record last updated":             "updated_date",
 		"domain datelastmodified":                "updated_date",
 		"modification date":                      "updated_date",
+		"transferred":                            "transferred_date",
+		"transfer date":                          "transferred_date",
+		"transferred date":                       "transferred_date",
+		"last transfer date":                     "transferred_date",
+		"last transferred":                       "transferred_date",
+		"last transferred date":                  "transferred_date",
+		"registrar transfer date":                "transferred_date",
+		"domain last transferred date":           "transferred_date",
 		"expire":                                 "expired_date",
 		"expires":                                "expired_date",
 		"expires on":                             "expired_date",
@@ -93,7 +120,8 @@ var (
 		"expired date":                           "expired_date",
 		"expiration date":                        "expired_date",
 		"expiration on":                          "expired_date",
-		"registrar registration expiration date": "expired_date",
+		"registrar registration expiration date": "registrar_expired_date",
+		"registrar expiration date":              "registrar_expired_date",
 		"domain expiration date":                 "expired_date",
 		"expiry date":                            "expired_date",
 		"expiration time":                        "expired_date",
@@ -110,7 +138,7 @@ var (
 		"registration service url":               "referral_url",
 		"registrant c":                           "registrant_id",
 		"registrant id":                          "registrant_id",
-		"registrant iana id":                     "registrant_id",
+		"registrant iana id":                     "registrant_iana_id",
 		"registrant contact id":                  "registrant_id",
 		"registrant register number":             "registrant_id",
 		"registrant id number":                   "registrant_id",
@@ -133,10 +161,16 @@ var (
 		"registrant contact organisation":        "registrant_organization",
 		"registrant company name":                "registrant_organization",
 		"registrant company english name":        "registrant_organization",
+		"registrant organization local":          "registrant_organization_local",
+		"registrant local organization":          "registrant_organization_local",
 		"registrant address":                     "registrant_street",
 		"registrant address1":                    "registrant_street",
+		"registrant address2":                    "registrant_street",
+		"registrant address3":                    "registrant_street",
 		"registrant street":                      "registrant_street",
 		"registrant street1":                     "registrant_street",
+		"registrant street2":                     "registrant_street",
+		"registrant street3":                     "registrant_street",
 		"registrant street address":              "registrant_street",
 		"registrant contact address":             "registrant_street",
 		"registrant contact address1":            "registrant_street",
@@ -150,6 +184,12 @@ var (
 		"registrant contact city":                "registrant_city",
 		"registrant state province":              "registrant_state_province",
 		"registrant contact state province":      "registrant_state_province",
+		"registrant state or province":           "registrant_state_province",
+		"registrant state":                       "registrant_state_province",
+		"registrant province":                    "registrant_state_province",
+		"registrant region":                      "registrant_state_province",
+		"registrant prefecture":                  "registrant_state_province",
+		"registrant oblast":                      "registrant_state_province",
 		"registrant zipcode":                     "registrant_postal_code",
 		"registrant zip code":                    "registrant_postal_code",
 		"registrant postalcode":                  "registrant_postal_code",
@@ -163,9 +203,12 @@ var (
 		"registrant phone number":                "registrant_phone",
 		"registrant contact phone":               "registrant_phone",
 		"registrant contact phone number":        "registrant_phone",
-		"registrant abuse contact phone":         "registrant_phone",
+		"registrant abuse contact phone":         "registrant_abuse_phone",
 		"registrant phone ext":                   "registrant_phone_ext",
+		"registrant phone extension":             "registrant_phone_ext",
+		"registrant phone number ext":            "registrant_phone_ext",
 		"registrant contact phone ext":           "registrant_phone_ext",
+		"registrant contact phone number ext":    "registrant_phone_ext",
 		"registrant fax":                         "registrant_fax",
 		"registrant fax no":                      "registrant_fax",
 		"registrant fax number":                  "registrant_fax",
@@ -175,8 +218,14 @@ var (
 		"registrant contact fax number":          "registrant_fax",
 		"registrant contact facsimile":           "registrant_fax",
 		"registrant contact facsimile number":    "registrant_fax",
+		"registrant telefax":                     "registrant_fax",
 		"registrant fax ext":                     "registrant_fax_ext",
+		"registrant fax extension":               "registrant_fax_ext",
+		"registrant fax number ext":              "registrant_fax_ext",
+		"registrant facsimile ext":               "registrant_fax_ext",
+		"registrant facsimile number ext":        "registrant_fax_ext",
 		"registrant contact fax ext":             "registrant_fax_ext",
+		"registrant contact fax number ext":      "registrant_fax_ext",
 		"registrant mail":                        "registrant_email",
 		"registrant email":                       "registrant_email",
 		"registrant e mail":                      "registrant_email",
@@ -185,5 +234,210 @@ var (
 		"registrant contact email":               "registrant_email",
 		"registrant contact e mail":              "registrant_email",
 		"registrant abuse contact email":         "registrant_email",
+		"registrant anonymous":                   "registrant_anonymous",
+		"email forwarding":                       "extension_email_forwarding",
+		"email forwarding id":                    "extension_email_forwarding_id",
+		"forwarded to":                           "extension_forwarded_to",
+		"ens authid":                             "extension_ens_auth_id",
+		"security contact email":                 "extension_security_contact_email",
+		"membership id":                          "extension_membership_id",
+		"membership status":                      "extension_membership_status",
+		"membership association":                 "extension_membership_association",
+		"member id":                              "extension_member_id",
+		"registrant member id":                   "extension_member_id",
+		"aviation community":                     "extension_aviation_community",
+		"registrant aviation community":          "extension_aviation_community",
+		"association":                            "extension_association",
+		"registrant association":                 "extension_association",
+		"association member id":                  "extension_association_member_id",
+		"registrant association member id":       "extension_association_member_id",
+		"industry classification":                "extension_industry_classification",
+		"registrant industry classification":     "extension_industry_classification",
+		"whois type":                             "extension_whois_type",
+		"naptr record":                           "extension_naptr",
+		"intended use":                           "extension_intended_use",
+		"domain language":                        "extension_language",
+		"eligibility":                            "extension_eligibility",
+		"profession":                             "extension_profession",
+		"registrant profession":                  "extension_profession",
+		"license number":                         "extension_license_number",
+		"registrant license number":              "extension_license_number",
+		"licensing authority":                    "extension_licensing_authority",
+		"registrant licensing authority":         "extension_licensing_authority",
+		"authority name":                         "extension_licensing_authority",
+		"authority website":                      "extension_licensing_authority_url",
+		"licensing authority website":            "extension_licensing_authority_url",
+		"jurisdiction":                           "extension_jurisdiction",
+		"registrant jurisdiction":                "extension_jurisdiction",
+		"ced id":                                 "extension_ced_id",
+		"registrant ced id":                      "extension_ced_id",
+		"ced place of domicile":                  "extension_ced_place_of_domicile",
+		"ced country":                            "extension_ced_place_of_domicile",
+		"ced city":                               "extension_ced_city",
+		"ced state province":                     "extension_ced_province",
+		"ced legal form of entity":               "extension_ced_legal_form",
+		"ced legal entity type":                  "extension_ced_legal_form",
+		"ced legal form other":                   "extension_ced_legal_form_other",
+		"ced identification type":                "extension_ced_identification_type",
+		"ced identification form":                "extension_ced_identification_type",
+		"ced identification type other":          "extension_ced_identification_type_other",
+		"ced identification number":              "extension_ced_identification_number",
+		"uin":                                    "extension_uin",
+		"registrant uin":                         "extension_uin",
+		"travel uin":                             "extension_uin",
+		"unique identification number":           "extension_uin",
+		"anniversary":                            "extension_anniversary",
+		"verification status":                    "extension_verification_status",
+		"registrant verification status":         "extension_verification_status",
+		"cooperative verification status":        "extension_verification_status",
+		"registrant cooperative verification":    "extension_verification_status",
+	}
+
+	// eppKeys is the canonical key set of the ICANN gTLD EPP format
+	eppKeys = []string{
+		"Domain Name",
+		"Registry Domain ID",
+		"Registrar WHOIS Server",
+		"Registrar URL",
+		"Updated Date",
+		"Creation Date",
+		"Registry Expiry Date",
+		"Registrar Registration Expiration Date",
+		"Registrar",
+		"Registrar IANA ID",
+		"Sponsoring Registrar IANA ID",
+		"Registrar Abuse Contact Email",
+		"Registrar Abuse Contact Phone",
+		"Reseller",
+		"Domain Status",
+		"Registry Registrant ID",
+		"Registrant Name",
+		"Registrant Organization",
+		"Registrant Street",
+		"Registrant City",
+		"Registrant State/Province",
+		"Registrant Postal Code",
+		"Registrant Country",
+		"Registrant Phone",
+		"Registrant Phone Ext",
+		"Registrant Fax",
+		"Registrant Fax Ext",
+		"Registrant Email",
+		"Registry Admin ID",
+		"Admin Name",
+		"Admin Organization",
+		"Admin Street",
+		"Admin City",
+		"Admin State/Province",
+		"Admin Postal Code",
+		"Admin Country",
+		"Admin Phone",
+		"Admin Phone Ext",
+		"Admin Fax",
+		"Admin Fax Ext",
+		"Admin Email",
+		"Registry Tech ID",
+		"Tech Name",
+		"Tech Organization",
+		"Tech Street",
+		"Tech City",
+		"Tech State/Province",
+		"Tech Postal Code",
+		"Tech Country",
+		"Tech Phone",
+		"Tech Phone Ext",
+		"Tech Fax",
+		"Tech Fax Ext",
+		"Tech Email",
+		"Registry Billing ID",
+		"Billing Name",
+		"Billing Organization",
+		"Billing Street",
+		"Billing City",
+		"Billing State/Province",
+		"Billing Postal Code",
+		"Billing Country",
+		"Billing Phone",
+		"Billing Phone Ext",
+		"Billing Fax",
+		"Billing Fax Ext",
+		"Billing Email",
+		"Name Server",
+		"DNSSEC",
+	}
+
+	// eppKeyRule is the precomputed key rule of eppKeys for the EPP fast path
+	eppKeyRule = buildKeyRule(eppKeys)
+
+	// contactSections is the contact role of section headers, the fields under a header belong to the contact
+	contactSections = map[string]string{
+		"registrar information":              "registrar",
+		"registrant contact":                 "registrant",
+		"registrant contact information":     "registrant",
+		"owner contact":                      "registrant",
+		"holder contact":                     "registrant",
+		"admin contact":                      "admin",
+		"administrative contact":             "admin",
+		"administrative contact information": "admin",
+		"tech contact":                       "tech",
+		"technical contact":                  "tech",
+		"technical contact information":      "tech",
+		"billing contact":                    "billing",
+		"billing contact information":        "billing",
+	}
+
+	// contactSectionsMu guards contactSections against RegisterContactSection
+	contactSectionsMu sync.RWMutex
+
+	// contactSectionRoles is the key prefix of contact section by the role of ContactRoles and the short names
+	contactSectionRoles = map[string]string{
+		RoleRegistrar:      "registrar",
+		RoleRegistrant:     "registrant",
+		RoleAdministrative: "admin",
+		"admin":            "admin",
+		RoleTechnical:      "tech",
+		"tech":             "tech",
+		RoleBilling:        "billing",
+		"bill":             "billing",
 	}
 )
+
+// buildKeyRule returns the precomputed parsing rules of keys
+func buildKeyRule(keys []string) map[string]whoisKey {
+	rules := make(map[string]whoisKey, len(keys))
+	for _, v := range keys {
+		rules[v] = searchWhoisKey(v, "")
+	}
+
+	return rules
+}
+
+// RegisterContactSection registers the section header of a contact block, like "Registrant Contact Information",
+// fields under the header are parsed into the contact of role until a blank line, role is one of ContactRoles,
+// the short names admin, tech and bill are accepted, it returns ErrUnknownContactRole if role is unknown
+func RegisterContactSection(header, role string) error {
+	prefix, ok := contactSectionRoles[strings.ToLower(strings.TrimSpace(role))]
+	if !ok {
+		return ErrUnknownContactRole
+	}
+
+	contactSectionsMu.Lock()
+	defer contactSectionsMu.Unlock()
+
+	contactSections[clearKeyName(strings.TrimSuffix(strings.TrimSpace(header), ":"))] = prefix
+
+	return nil
+}
+
+// searchContactSection returns the contact role if line is a registered section header
+func searchContactSection(line string) string {
+	name := strings.TrimSuffix(line, ":")
+	if name == "" || strings.Contains(name, ":") {
+		return ""
+	}
+
+	contactSectionsMu.RLock()
+	defer contactSectionsMu.RUnlock()
+
+	return contactSections[clearKeyName(name)]
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+// strictFields is the expected fields of registered domain, IP and AS by response type,
+// gTLD responses follow the ICANN registration data policy, ccTLD formats differ too much to expect more
+var strictFields = map[string][]string{
+	"epp": {
+		"domain.id",
+		"domain.domain",
+		"domain.created_date",
+		"domain.expiration_date",
+		"registrar.name",
+	},
+	"domain": {
+		"domain.domain",
+	},
+	"ip": {
+		"ip.networks",
+	},
+	"as": {
+		"as.number",
+	},
+}
+
+// ParseStrict returns parsed whois info like Parse, but returns a *MissingFieldsError listing
+// the expected fields which are missing, parsed whois info is returned along with the error
+func ParseStrict(text string, opts ...Option) (whoisInfo WhoisInfo, err error) {
+	whoisInfo, err = Parse(text, opts...)
+	if err != nil {
+		return
+	}
+
+	text, _ = normalizeWhois(text)
+	if missing := missingFields(whoisInfo, responseType(text)); len(missing) > 0 {
+		err = &MissingFieldsError{Fields: missing}
+	}
+
+	return
+}
+
+// responseType returns the strict fields type of whois text
+func responseType(text string) string {
+	switch {
+	case isASWhois(text):
+		return "as"
+	case isIPWhois(text):
+		return "ip"
+	case isEPPWhois(text):
+		return "epp"
+	default:
+		return "domain"
+	}
+}
+
+// missingFields returns the expected fields of response type which are empty in whois info
+func missingFields(whoisInfo WhoisInfo, typ string) []string {
+	missing := []string{}
+	for _, v := range strictFields[typ] {
+		if isMissingField(whoisInfo, v) {
+			missing = append(missing, v)
+		}
+	}
+
+	return missing
+}
+
+// isMissingField returns if the field of whois info is empty
+func isMissingField(whoisInfo WhoisInfo, field string) bool {
+	d := whoisInfo.Domain
+	if d == nil {
+		d = &Domain{}
+	}
+
+	switch field {
+	case "domain.id":
+		return d.ID == ""
+	case "domain.domain":
+		return d.Domain == ""
+	case "domain.created_date":
+		return d.CreatedDate == ""
+	case "domain.expiration_date":
+		return d.ExpirationDate == ""
+	case "registrar.name":
+		return whoisInfo.Registrar == nil || whoisInfo.Registrar.Name == ""
+	case "ip.networks":
+		return whoisInfo.IP == nil || len(whoisInfo.IP.Networks) == 0
+	case "as.number":
+		return whoisInfo.AS == nil || whoisInfo.AS.Number == ""
+	default:
+		return false
+	}
+}
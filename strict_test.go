@@ -0,0 +1,77 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestParseStrict(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := ParseStrict(whoisRaw)
+	assert.Nil(t, err)
+	assert.Equal(t, whoisInfo.Domain.Domain, "google.com")
+
+	lines := []string{}
+	for _, v := range strings.Split(whoisRaw, "\n") {
+		if !strings.HasPrefix(v, "Creation Date:") && !strings.HasPrefix(v, "Registrar:") {
+			lines = append(lines, v)
+		}
+	}
+
+	whoisInfo, err = ParseStrict(strings.Join(lines, "\n"))
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrMissingFields))
+	assert.Equal(t, err.Error(), "whoisparser: expected fields are missing: domain.created_date, registrar.name")
+
+	var missingErr *MissingFieldsError
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, missingErr.Fields, []string{"domain.created_date", "registrar.name"})
+	assert.Equal(t, whoisInfo.Domain.Domain, "google.com")
+	assert.Equal(t, whoisInfo.Domain.ExpirationDate, "2028-09-13T00:00:00-0700")
+
+	// thin .name registry response has no dates
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/name_github.name")
+	assert.Nil(t, err)
+
+	_, err = ParseStrict(whoisRaw)
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, missingErr.Fields, []string{"domain.created_date", "domain.expiration_date"})
+
+	// ccTLD only expects the domain name
+	whoisRaw, err = xfile.ReadText(noterrorDir + "/de_git.de")
+	assert.Nil(t, err)
+
+	_, err = ParseStrict(whoisRaw)
+	assert.Nil(t, err)
+
+	whoisRaw, err = xfile.ReadText(notfoundDir + "/com_likexian-have-no-money-to-register.com")
+	assert.Nil(t, err)
+
+	_, err = ParseStrict(whoisRaw)
+	assert.Equal(t, err, ErrNotFoundDomain)
+}
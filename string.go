@@ -0,0 +1,95 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns a compact multi-line summary of whois info for logs, like
+//
+//	Domain: google.com
+//	Status: clientDeleteProhibited, clientTransferProhibited
+//	Created: 1997-09-15T04:00:00Z
+//	Registrar: MarkMonitor, Inc. (292)
+//
+// nil and empty fields are skipped, use encoding/json for the full data.
+func (w WhoisInfo) String() string {
+	lines := []string{}
+	add := func(name string, values ...string) {
+		result := []string{}
+		for _, v := range values {
+			if v != "" {
+				result = append(result, v)
+			}
+		}
+		if len(result) > 0 {
+			lines = append(lines, name+": "+strings.Join(result, " "))
+		}
+	}
+
+	if d := w.Domain; d != nil {
+		add("Domain", d.Domain)
+		add("Status", strings.Join(d.Status, ", "))
+		add("Name Servers", strings.Join(d.NameServers, ", "))
+		add("Created", d.CreatedDate)
+		add("Updated", d.UpdatedDate)
+		add("Expires", d.ExpirationDate)
+	}
+
+	if c := w.Registrar; c != nil {
+		add("Registrar", c.Name, contactID(c.ID))
+	}
+
+	if c := w.Registrant; c != nil {
+		add("Registrant", c.DisplayName(), contactEmail(c.Email))
+	}
+
+	if w.IP != nil {
+		for _, v := range w.IP.Networks {
+			add("Network", v.Range, v.Name)
+		}
+	}
+
+	if a := w.AS; a != nil {
+		add("AS", a.Number, a.Name)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// contactID returns the contact id in parentheses
+func contactID(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("(%s)", id)
+}
+
+// contactEmail returns the contact email in angle brackets
+func contactEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("<%s>", email)
+}
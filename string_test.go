@@ -0,0 +1,61 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for domain whois information parsing
+ * https://www.likexian.com/
+ */
+
+package whoisparser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func TestWhoisInfoString(t *testing.T) {
+	whoisRaw, err := xfile.ReadText(noterrorDir + "/com_google.com")
+	assert.Nil(t, err)
+
+	whoisInfo, err := Parse(whoisRaw)
+	assert.Nil(t, err)
+
+	text := whoisInfo.String()
+	assert.Contains(t, text, "Domain: google.com\n")
+	assert.Contains(t, text, "Status: clientUpdateProhibited, clientTransferProhibited, clientDeleteProhibited")
+	assert.Contains(t, text, "Name Servers: ns2.google.com, ns3.google.com, ns4.google.com, ns1.google.com\n")
+	assert.Contains(t, text, "Created: 1997-09-15T00:00:00-0700\n")
+	assert.Contains(t, text, "Expires: "+whoisInfo.Domain.ExpirationDate)
+	assert.Contains(t, text, "Registrar: MarkMonitor, Inc. (292)\n")
+	assert.Contains(t, text, "Registrant: Google LLC")
+	assert.Equal(t, fmt.Sprint(whoisInfo), text)
+
+	// nil and empty fields are skipped
+	whoisInfo = WhoisInfo{
+		Domain:     &Domain{Domain: "example.com", Status: []string{"ok"}},
+		Registrant: &Contact{Name: "Example Owner", Email: "owner@example.com"},
+	}
+	assert.Equal(t, whoisInfo.String(), "Domain: example.com\nStatus: ok\nRegistrant: Example Owner <owner@example.com>")
+	assert.False(t, strings.Contains(whoisInfo.String(), "Registrar"))
+
+	whoisInfo = WhoisInfo{Registrant: &Contact{ID: "C123"}}
+	assert.Equal(t, whoisInfo.String(), "Registrant: C123")
+
+	assert.Equal(t, WhoisInfo{}.String(), "")
+	assert.Equal(t, WhoisInfo{AS: &ASInfo{Number: "15169", Name: "GOOGLE"}}.String(), "AS: 15169 GOOGLE")
+}
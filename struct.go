@@ -23,54 +23,99 @@ import "time"
 
 // WhoisInfo stores domain, IP, or AS WHOIS information.
 type WhoisInfo struct {
-	Domain         *Domain  `json:"domain,omitempty"`
-	Registrar      *Contact `json:"registrar,omitempty"`
-	Registrant     *Contact `json:"registrant,omitempty"`
-	Administrative *Contact `json:"administrative,omitempty"`
-	Technical      *Contact `json:"technical,omitempty"`
-	Billing        *Contact `json:"billing,omitempty"`
-	IP             *IPInfo  `json:"ip,omitempty"`
-	AS             *ASInfo  `json:"as,omitempty"`
+	Domain         *Domain           `json:"domain,omitempty"`
+	Registrar      *Contact          `json:"registrar,omitempty"`
+	Registrant     *Contact          `json:"registrant,omitempty"`
+	Administrative *Contact          `json:"administrative,omitempty"`
+	Technical      *Contact          `json:"technical,omitempty"`
+	Billing        *Contact          `json:"billing,omitempty"`
+	IP             *IPInfo           `json:"ip,omitempty"`
+	AS             *ASInfo           `json:"as,omitempty"`
+	Extensions     map[string]string `json:"extensions,omitempty"`
+	ParserVersion  string            `json:"parser_version,omitempty"`
 }
 
 // Domain stores domain name information.
 type Domain struct {
-	ID                   string     `json:"id,omitempty"`
-	Domain               string     `json:"domain,omitempty"`
-	Punycode             string     `json:"punycode,omitempty"`
-	Name                 string     `json:"name,omitempty"`
-	Extension            string     `json:"extension,omitempty"`
-	WhoisServer          string     `json:"whois_server,omitempty"`
-	Status               []string   `json:"status,omitempty"`
-	NameServers          []string   `json:"name_servers,omitempty"`
-	DNSSec               bool       `json:"dnssec,omitempty"`
-	CreatedDate          string     `json:"created_date,omitempty"`
-	CreatedDateInTime    *time.Time `json:"created_date_in_time,omitempty"`
-	UpdatedDate          string     `json:"updated_date,omitempty"`
-	UpdatedDateInTime    *time.Time `json:"updated_date_in_time,omitempty"`
-	ExpirationDate       string     `json:"expiration_date,omitempty"`
-	ExpirationDateInTime *time.Time `json:"expiration_date_in_time,omitempty"`
+	ID                            string              `json:"id,omitempty"`
+	Domain                        string              `json:"domain,omitempty"`
+	Punycode                      string              `json:"punycode,omitempty"`
+	Name                          string              `json:"name,omitempty"`
+	NameUnicode                   string              `json:"name_unicode,omitempty"`
+	Extension                     string              `json:"extension,omitempty"`
+	WhoisServer                   string              `json:"whois_server,omitempty"`
+	RegistrarWhoisServer          string              `json:"registrar_whois_server,omitempty"`
+	Reseller                      string              `json:"reseller,omitempty"`
+	ResellerEmail                 string              `json:"reseller_email,omitempty"`
+	ResellerURL                   string              `json:"reseller_url,omitempty"`
+	Status                        []string            `json:"status,omitempty"`
+	NameServers                   []string            `json:"name_servers,omitempty"`
+	NameServerIPs                 map[string][]string `json:"name_server_ips,omitempty"`
+	NameServersTruncated          bool                `json:"name_servers_truncated,omitempty"`
+	DNSSec                        bool                `json:"dnssec,omitempty"`
+	DNSSECStatus                  string              `json:"dnssec_status,omitempty"`
+	Remarks                       []string            `json:"remarks,omitempty"`
+	CreatedDate                   string              `json:"created_date,omitempty"`
+	CreatedDateInTime             *time.Time          `json:"created_date_in_time,omitempty"`
+	UpdatedDate                   string              `json:"updated_date,omitempty"`
+	UpdatedDateInTime             *time.Time          `json:"updated_date_in_time,omitempty"`
+	RegistrarUpdatedDate          string              `json:"registrar_updated_date,omitempty"`
+	RegistrarUpdatedDateInTime    *time.Time          `json:"registrar_updated_date_in_time,omitempty"`
+	TransferredDate               string              `json:"transferred_date,omitempty"`
+	TransferredDateInTime         *time.Time          `json:"transferred_date_in_time,omitempty"`
+	ExpirationDate                string              `json:"expiration_date,omitempty"`
+	ExpirationDateInTime          *time.Time          `json:"expiration_date_in_time,omitempty"`
+	RegistrarExpirationDate       string              `json:"registrar_expiration_date,omitempty"`
+	RegistrarExpirationDateInTime *time.Time          `json:"registrar_expiration_date_in_time,omitempty"`
 }
 
+// The DNSSEC status of Domain.DNSSECStatus, unknown if the whois response does not state it
+const (
+	DNSSECStatusUnknown  = "unknown"
+	DNSSECStatusSigned   = "signed"
+	DNSSECStatusUnsigned = "unsigned"
+)
+
 // Contact stores contact information.
 type Contact struct {
-	ID               string `json:"id,omitempty"`
-	Name             string `json:"name,omitempty"`
-	Organization     string `json:"organization,omitempty"`
-	Street           string `json:"street,omitempty"`
-	City             string `json:"city,omitempty"`
-	Province         string `json:"province,omitempty"`
-	PostalCode       string `json:"postal_code,omitempty"`
-	Country          string `json:"country,omitempty"`
-	Phone            string `json:"phone,omitempty"`
-	PhoneExt         string `json:"phone_ext,omitempty"`
-	Fax              string `json:"fax,omitempty"`
-	FaxExt           string `json:"fax_ext,omitempty"`
-	Email            string `json:"email,omitempty"`
-	ReferralURL      string `json:"referral_url,omitempty"`
-	RegistrationDate string `json:"registration_date,omitempty"`
-	Updated          string `json:"updated,omitempty"`
-	Comment          string `json:"comment,omitempty"`
+	ID                string   `json:"id,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Organization      string   `json:"organization,omitempty"`
+	OrganizationLocal string   `json:"organization_local,omitempty"`
+	Street            string   `json:"street,omitempty"`
+	City              string   `json:"city,omitempty"`
+	Province          string   `json:"province,omitempty"`
+	PostalCode        string   `json:"postal_code,omitempty"`
+	Country           string   `json:"country,omitempty"`
+	CountryCode       string   `json:"country_code,omitempty"`
+	Phone             string   `json:"phone,omitempty"`
+	PhoneExt          string   `json:"phone_ext,omitempty"`
+	PhoneE164         string   `json:"phone_e164,omitempty"`
+	Fax               string   `json:"fax,omitempty"`
+	FaxExt            string   `json:"fax_ext,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	Emails            []string `json:"emails,omitempty"`
+	ReferralURL       string   `json:"referral_url,omitempty"`
+	RegistrationDate  string   `json:"registration_date,omitempty"`
+	Updated           string   `json:"updated,omitempty"`
+	Comment           string   `json:"comment,omitempty"`
+	Redacted          bool     `json:"redacted,omitempty"`
+}
+
+// Warning stores a whois line which is skipped or ambiguous in parsing.
+type Warning struct {
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Message string `json:"message"`
+}
+
+// FieldChange stores the change of a whois field between two snapshots.
+type FieldChange struct {
+	Field   string   `json:"field"`
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
 }
 
 // IPInfo stores IP WHOIS information.
@@ -0,0 +1,40 @@
+% This is the MARnet WHOIS query service.
+% The objects are in RPSL format, query like
+% domain: marnet.mk
+%
+% The MARnet WHOIS is subject to Terms and Conditions.
+
+% Information related to 'example-commented.mk'
+
+domain:       example-commented.mk
+registrant:   EXCM1-MK
+admin-c:      EXCM1-MK
+tech-c:       EXCM2-MK
+nserver:      ns1.example-commented.mk
+nserver:      ns2.example-commented.mk
+status:       registered
+registrar:    Example Registrar DOOEL
+registered:   21.06.2018
+changed:      14.05.2024
+expire:       21.06.2026
+source:       MK-NIC
+
+% Information related to 'EXCM1-MK'
+person:       Ana Angelovska
+address:      Ilindenska 40
+address:      1200 Tetovo
+country:      MK
+e-mail:       ana@example-commented.mk
+nic-hdl:      EXCM1-MK
+source:       MK-NIC
+
+% Information related to 'EXCM2-MK'
+role:         Example Commented NOC
+address:      Ilindenska 40
+address:      1200 Tetovo
+country:      MK
+e-mail:       noc@example-commented.mk
+nic-hdl:      EXCM2-MK
+source:       MK-NIC
+
+% This query was served by the MARnet WHOIS (1.2.3)
@@ -0,0 +1,40 @@
+% MARnet WHOIS server
+% This query returned 4 objects
+
+domain:       example-org.mk
+registrant:   EXORG1-MK
+org:          ORG-EXO1-MK
+admin-c:      EXORG1-MK
+tech-c:       EXORG2-MK
+nserver:      ns1.example-org.mk
+nserver:      ns2.example-org.mk
+status:       registered
+registrar:    Example Registrar DOOEL
+registered:   11.09.2015
+changed:      03.03.2024
+expire:       11.09.2025
+source:       MK-NIC
+
+organisation: ORG-EXO1-MK
+org-name:     Example Org DOOEL
+address:      Makedonija 20
+address:      1000 Skopje
+country:      MK
+source:       MK-NIC
+
+person:       Marko Markovski
+address:      Makedonija 20
+address:      1000 Skopje
+country:      MK
+e-mail:       marko@example-org.mk
+nic-hdl:      EXORG1-MK
+source:       MK-NIC
+
+role:         Example Org NOC
+org:          ORG-EXO1-MK
+address:      Makedonija 20
+address:      1000 Skopje
+country:      MK
+e-mail:       noc@example-org.mk
+nic-hdl:      EXORG2-MK
+source:       MK-NIC
@@ -0,0 +1,40 @@
+% MARnet WHOIS server
+% This query returned 4 objects
+
+domain:       example.mk
+registrant:   EXMK1-MK
+admin-c:      EXMK2-MK
+tech-c:       EXMK3-MK
+nserver:      ns1.example.mk
+nserver:      ns2.example.mk
+status:       registered
+registrar:    Example Registrar DOOEL
+registered:   05.04.2010
+changed:      12.02.2024
+expire:       05.04.2025
+source:       MK-NIC
+
+person:       Example DOOEL
+address:      Partizanska 12
+address:      1000 Skopje
+country:      MK
+phone:        +389 2 3123 456
+e-mail:       info@example.mk
+nic-hdl:      EXMK1-MK
+source:       MK-NIC
+
+person:       Petar Petrovski
+address:      Partizanska 12
+address:      1000 Skopje
+country:      MK
+e-mail:       admin@example.mk
+nic-hdl:      EXMK2-MK
+source:       MK-NIC
+
+role:         Example Hosting Team
+address:      Ilindenska 5
+address:      1000 Skopje
+country:      MK
+e-mail:       noc@example-hosting.mk
+nic-hdl:      EXMK3-MK
+source:       MK-NIC
@@ -0,0 +1,61 @@
+
+   Domain name:
+      EXAMPLE.ML is Active
+
+   Owner contact:
+      Organization: Example Mali SARL
+      Name:         Moussa Traore
+      Address:      Avenue de l'Independance 12
+      Zipcode:      BP 2480
+      City:         Bamako
+      State:        District de Bamako
+      Country:      Mali
+      Phone:        +223 20 22 11 00
+      Fax:          +223 20 22 11 01
+      E-mail:       hostmaster@example.ml
+
+   Admin contact:
+      Organization: Example Mali SARL
+      Name:         Moussa Traore
+      Address:      Avenue de l'Independance 12
+      Zipcode:      BP 2480
+      City:         Bamako
+      State:        District de Bamako
+      Country:      Mali
+      Phone:        +223 20 22 11 00
+      Fax:          +223 20 22 11 01
+      E-mail:       hostmaster@example.ml
+
+   Billing contact:
+      Organization: Example Mali SARL
+      Name:         Awa Keita
+      Address:      Avenue de l'Independance 12
+      Zipcode:      BP 2480
+      City:         Bamako
+      State:        District de Bamako
+      Country:      Mali
+      Phone:        +223 20 22 11 02
+      Fax:          +223 20 22 11 01
+      E-mail:       billing@example.ml
+
+   Tech contact:
+      Organization: Example Hosting Ltd.
+      Name:         Network Operations
+      Address:      10 Harbour Road
+      Zipcode:      10115
+      City:         Berlin
+      State:        Berlin
+      Country:      Germany
+      Phone:        +49 30 1234567
+      Fax:          +49 30 1234568
+      E-mail:       noc@example-hosting.de
+
+   Domain Nameservers:
+      NS1.EXAMPLE-HOSTING.DE
+      NS2.EXAMPLE-HOSTING.DE
+
+   Domain registered: 04/07/2015
+   Record will expire on: 04/07/2025
+   Record maintained by: Point ML Domain Registry
+
+
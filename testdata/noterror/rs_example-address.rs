@@ -0,0 +1,33 @@
+%
+%This is the RNIDS Whois server.
+%
+% Date Format         : DD.MM.YYYY
+% Whois Server Version: 1.0.0
+%
+% Rights restricted by copyright.
+% See http://www.rnids.rs/whois_en
+%
+
+Domain name: example-address.rs
+Domain status: Active
+Registration date: 14.09.2016 09:12:45
+Modification date: 02.08.2024 11:20:03
+Expiration date: 14.09.2026 09:12:45
+Registrar: Mainstream d.o.o.
+
+
+DNS: ns1.example-address.rs - 
+DNS: ns2.example-address.rs - 
+
+
+
+Registrant: Primer Digital d.o.o.
+Address: Knez Mihailova 12, Beograd, Serbia
+ID Number: 21012345
+Tax ID: 109876543
+
+Administrative contact: Jovan Jovanovic
+Address: Bulevar oslobodjenja 5, 21000 Novi Sad, Serbia
+
+Technical contact: Example Hosting Ltd
+Address: Main Street 1, Suite 2, Dublin, Ireland
@@ -0,0 +1,11 @@
+% This is the MARnet WHOIS query service.
+% The objects are in RPSL format, query like
+% domain: marnet.mk
+%
+% The MARnet WHOIS is subject to Terms and Conditions.
+
+%ERROR:101: no entries found
+%
+% No entries found in source MK-NIC.
+
+% This query was served by the MARnet WHOIS (1.2.3)
@@ -0,0 +1 @@
+Invalid query or domain name not known in Point ML Domain Registry
@@ -21,9 +21,19 @@ package whoisparser
 
 import (
 	"fmt"
+	"html"
+	"mime"
+	"net"
+	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/likexian/gokit/assert"
+	"golang.org/x/net/idna"
 )
 
 // isDNSSecEnabled returns if domain dnssec is enabled
@@ -36,6 +46,17 @@ func isDNSSecEnabled(data string) bool {
 	}
 }
 
+// isDNSSecDisabled returns if domain dnssec is explicitly disabled
+func isDNSSecDisabled(data string) bool {
+	data = strings.ToLower(data)
+	switch data {
+	case "no", "inactive", "미서명":
+		return true
+	default:
+		return strings.HasPrefix(data, "unsigned")
+	}
+}
+
 // clearKeyName returns cleared key name
 func clearKeyName(key string) string {
 	if strings.Contains(key, "(") {
@@ -49,11 +70,10 @@ func clearKeyName(key string) string {
 	key = strings.Replace(key, "'", " ", -1)
 	key = strings.Replace(key, ".", " ", -1)
 
-	key = strings.TrimPrefix(key, "Registry ")
-	key = strings.TrimPrefix(key, "Sponsoring ")
-
+	key = strings.ToLower(strings.TrimSpace(key))
+	key = strings.TrimPrefix(key, "registry ")
+	key = strings.TrimPrefix(key, "sponsoring ")
 	key = strings.TrimSpace(key)
-	key = strings.ToLower(key)
 
 	return key
 }
@@ -68,27 +88,364 @@ func searchKeyName(key string) string {
 	return ""
 }
 
+// whoisKey stores the parsing rule of a whois key
+type whoisKey struct {
+	rule    string
+	contact string
+	field   string
+}
+
+// searchWhoisKey returns the parsing rule by key, contact keys are split into contact and field
+func searchWhoisKey(key, extension string) whoisKey {
+	rule := searchKeyName(key)
+	if rule != "" && !strings.HasPrefix(rule, "registrant_") {
+		return whoisKey{rule: rule}
+	}
+
+	name := clearKeyName(key)
+	if !strings.Contains(name, " ") {
+		if name == "registrar" {
+			name += " name"
+		} else if extension == "dk" {
+			name = "registrant " + name
+		} else {
+			name += " organization"
+		}
+	}
+
+	ns := strings.SplitN(name, " ", 2)
+	field := searchKeyName("registrant " + ns[1])
+
+	// the remarks of contact block are of the domain, kept out of contact fields
+	if field == "domain_remarks" {
+		return whoisKey{rule: field}
+	}
+
+	return whoisKey{
+		rule:    rule,
+		contact: ns[0],
+		field:   field,
+	}
+}
+
+// statusPhrases is the prose domain status that are kept as a whole
+var statusPhrases = []string{
+	"pending delete",
+	"pending restore",
+	"redemption period",
+	"auto renew period",
+	"to be suspended",
+	"client transfer prohibited",
+	"server transfer prohibited",
+	"client delete prohibited",
+	"server delete prohibited",
+	"client update prohibited",
+	"server update prohibited",
+	"client renew prohibited",
+	"server renew prohibited",
+}
+
 // fixDomainStatus returns fixed domain status
 func fixDomainStatus(status []string) []string {
 	for k, v := range status {
 		names := strings.Split(strings.TrimSpace(v), " ")
 		status[k] = names[0]
+		// the description may be attached without space, like "ok(active)"
+		if i := strings.Index(status[k], "("); i > 0 {
+			status[k] = status[k][:i]
+		}
+		// the minimal status may be quoted or end with a period, the ok code is in lower case as EPP
+		status[k] = strings.TrimRight(strings.Trim(status[k], "\"'"), ".")
+		if strings.EqualFold(status[k], "ok") {
+			status[k] = "ok"
+		}
 		if strings.ToLower(status[k]) == "not" && len(names) > 1 && strings.ToLower(names[1]) == "delegated" {
 			status[k] = "not delegated"
 		}
+		for _, p := range statusPhrases {
+			n := strings.Count(p, " ") + 1
+			if len(names) >= n && strings.ToLower(strings.Join(names[:n], " ")) == p {
+				status[k] = strings.Join(names[:n], " ")
+				break
+			}
+		}
 	}
 
 	return status
 }
 
+// statusDescriptionRx matches the status code with the trailing description in parentheses, like "ok (active)"
+var statusDescriptionRx = regexp.MustCompile(`^([^\s(]+)\s*\(([^()]+)\)$`)
+
+// statusDescription returns the code and the description in parentheses of status,
+// the url of EPP status code is not a description
+func statusDescription(status string) (code, description string) {
+	m := statusDescriptionRx.FindStringSubmatch(strings.TrimSpace(status))
+	if m == nil || strings.Contains(m[2], "://") {
+		return "", ""
+	}
+
+	return m[1], strings.TrimSpace(m[2])
+}
+
 // fixNameServers returns fixed name servers
-func fixNameServers(servers []string) []string {
-	for k, v := range servers {
-		names := strings.Split(strings.TrimSpace(v), " ")
-		servers[k] = strings.ToLower(strings.Trim(names[0], "."))
+// the glue records like "ns1.example.com 192.0.2.1" or "ns1.example.com (192.0.2.1)" are returned as ips of the server
+func fixNameServers(servers []string) ([]string, map[string][]string) {
+	result := []string{}
+	ips := map[string][]string{}
+
+	for _, v := range servers {
+		names := strings.Fields(v)
+		if len(names) == 0 {
+			result = append(result, "")
+			continue
+		}
+
+		// glue split off by comma belongs to the previous server
+		name := strings.ToLower(strings.Trim(names[0], "."))
+		if ip := glueIP(names[0]); ip != "" && len(result) > 0 {
+			name = result[len(result)-1]
+		} else {
+			result = append(result, name)
+			names = names[1:]
+		}
+
+		for _, vv := range names {
+			if ip := glueIP(vv); ip != "" && !assert.IsContains(ips[name], ip) {
+				ips[name] = append(ips[name], ip)
+			}
+		}
 	}
 
-	return servers
+	if len(ips) == 0 {
+		ips = nil
+	}
+
+	return result, ips
+}
+
+// nameServerName returns the lower case name of name server value, or empty if it is a glue ip address
+func nameServerName(value string) string {
+	names := strings.Fields(value)
+	if len(names) == 0 || glueIP(names[0]) != "" {
+		return ""
+	}
+
+	return strings.ToLower(strings.Trim(names[0], "."))
+}
+
+// glueIP returns the ip address of glue record value, or empty if it is not an ip address
+func glueIP(value string) string {
+	ip := net.ParseIP(strings.Trim(value, "()[],;"))
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
+
+// unicodeDomain returns the Unicode form of A-label domain, or empty if it is not valid punycode,
+// the decoded domain must encode back into the same A-label
+func unicodeDomain(domain string) string {
+	result, err := idna.Lookup.ToUnicode(domain)
+	if err != nil {
+		return ""
+	}
+
+	if ascii, err := idna.Lookup.ToASCII(result); err != nil || ascii != strings.ToLower(domain) {
+		return ""
+	}
+
+	return result
+}
+
+// countryCode returns the upper case country code if the contact country is a two-letter code,
+// otherwise empty, the country name is not looked up
+func countryCode(country string) string {
+	country = strings.TrimSpace(country)
+	if len(country) != 2 {
+		return ""
+	}
+
+	for _, v := range country {
+		if !(v >= 'a' && v <= 'z' || v >= 'A' && v <= 'Z') {
+			return ""
+		}
+	}
+
+	return strings.ToUpper(country)
+}
+
+// maxLineLength is the max length of whois line, a whois line is far less than it
+const maxLineLength = 1 << 20
+
+// checkLineLength returns ErrLineTooLong if any line of text is longer than maxLineLength,
+// so an overlong line is rejected before it is split, copied and matched by the parser
+func checkLineLength(text string) error {
+	for len(text) > maxLineLength {
+		i := strings.IndexAny(text, "\r\n")
+		if i < 0 || i > maxLineLength {
+			return ErrLineTooLong
+		}
+		text = text[i+1:]
+	}
+
+	return nil
+}
+
+// stripBOM returns text without the leading UTF-8 byte order mark some whois servers prepend
+func stripBOM(text string) string {
+	return strings.TrimPrefix(text, "\ufeff")
+}
+
+var (
+	htmlWhoisRx = regexp.MustCompile(`^\s*<[!a-zA-Z]`)
+	htmlPreRx   = regexp.MustCompile(`(?is)<pre[^>]*>(.*?)</pre>`)
+	htmlBreakRx = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6])>`)
+	htmlTagRx   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// unwrapHTML returns the whois text of response wrapped in HTML by web proxied sources,
+// the content of pre tags is preferred, tags are stripped and entities are decoded,
+// text that does not start with a tag is returned as is
+func unwrapHTML(text string) string {
+	if !htmlWhoisRx.MatchString(text) {
+		return text
+	}
+
+	if m := htmlPreRx.FindAllStringSubmatch(text, -1); len(m) > 0 {
+		pres := []string{}
+		for _, v := range m {
+			pres = append(pres, v[1])
+		}
+		text = strings.Join(pres, "\n")
+	}
+
+	text = htmlBreakRx.ReplaceAllString(text, "\n")
+	text = htmlTagRx.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.Replace(text, "\u00a0", " ", -1)
+
+	return strings.TrimSpace(text)
+}
+
+// fixLineBreaks returns text with CRLF and lone CR line breaks converted to LF
+func fixLineBreaks(text string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+
+	return text
+}
+
+// normalizeWhois returns whois text ready for parsing, without the BOM, with LF line breaks and unwrapped from HTML,
+// ErrLineTooLong is returned if a line is too long for a whois response
+func normalizeWhois(text string) (string, error) {
+	if err := checkLineLength(text); err != nil {
+		return "", err
+	}
+
+	return unwrapHTML(fixLineBreaks(stripBOM(text))), nil
+}
+
+// emailRx matches a single email address
+var emailRx = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// splitEmails returns email addresses split by comma or semicolon,
+// nil is returned if data is not a list of email addresses, like "redacted for privacy" or an URL
+func splitEmails(data string) []string {
+	emails := []string{}
+	for _, v := range strings.FieldsFunc(data, func(r rune) bool { return r == ',' || r == ';' }) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !emailRx.MatchString(v) {
+			return nil
+		}
+		emails = append(emails, v)
+	}
+
+	return emails
+}
+
+var (
+	contactLineEmailRx = regexp.MustCompile(`<?([^\s<>()\[\],;/|]+@[^\s<>()\[\],;/|]+\.[^\s<>()\[\],;/|]+)>?`)
+	contactLinePhoneRx = regexp.MustCompile(`(?:^|[\s,;/|])(\+?\(?[0-9][0-9.\-\s()]{5,}[0-9])(?:$|[\s,;/|])`)
+)
+
+// splitContactLine returns the name, phone and email packed into a contact line,
+// like "Name, Email" or "Name / Phone / Email", the phone needs 7 digits at least and the + prefix or separators,
+// so the bare numbers like customer and company IDs are kept in the name
+func splitContactLine(line string) (name, phone, email string) {
+	if m := contactLineEmailRx.FindStringSubmatchIndex(line); m != nil {
+		email = line[m[2]:m[3]]
+		line = line[:m[0]] + "\x00" + line[m[1]:]
+	}
+
+	if m := contactLinePhoneRx.FindStringSubmatchIndex(line); m != nil {
+		digits := 0
+		for _, r := range line[m[2]:m[3]] {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		v := line[m[2]:m[3]]
+		if digits >= 7 && (strings.ContainsAny(v[:1], "+(") || strings.ContainsAny(v, ".-() ")) {
+			phone = v
+			line = line[:m[2]] + "\x00" + line[m[3]:]
+		}
+	}
+
+	names := []string{}
+	for _, v := range strings.Split(line, "\x00") {
+		if v = strings.Trim(v, " ,;/|"); v != "" {
+			names = append(names, v)
+		}
+	}
+	name = strings.Join(names, " ")
+
+	return
+}
+
+// splitAddress returns the street, city and country of a combined address like "123 St, City, Country",
+// split at the last two commas, the city and country must have no digits, like postal codes,
+// otherwise the city and country are empty
+func splitAddress(address string) (street, city, country string) {
+	parts := strings.Split(address, ",")
+	if len(parts) < 3 {
+		return address, "", ""
+	}
+
+	for k, v := range parts {
+		parts[k] = strings.TrimSpace(v)
+		if parts[k] == "" {
+			return address, "", ""
+		}
+	}
+
+	n := len(parts)
+	city, country = parts[n-2], parts[n-1]
+	if strings.ContainsAny(city+country, "0123456789") {
+		return address, "", ""
+	}
+
+	return strings.Join(parts[:n-2], ", "), city, country
+}
+
+// isNonLatin returns if text has letters of script other than Latin, like the native script of CJK registries
+func isNonLatin(text string) bool {
+	for _, r := range text {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isZeroContact returns if contact has no data
+func isZeroContact(contact *Contact) bool {
+	return reflect.DeepEqual(*contact, Contact{})
 }
 
 // containsIn returns if any of substrs contains in data
@@ -115,18 +472,84 @@ func keys(m map[string]string) []string {
 	return r
 }
 
+// mimeWordDecoder decodes the MIME encoded-words, the charsets other than UTF-8, ISO-8859-1 and US-ASCII are kept
+var mimeWordDecoder = &mime.WordDecoder{}
+
+// decodeMIMEWords returns the value with the MIME encoded-words decoded, like "=?UTF-8?Q?Soci=C3=A9t=C3=A9?="
+// and "=?UTF-8?B?U29jacOpdMOp?=" from the RDAP bridges, the plain or malformed value is returned as it is
+func decodeMIMEWords(value string) string {
+	if !strings.Contains(value, "=?") || !strings.Contains(value, "?=") {
+		return value
+	}
+
+	decoded, err := mimeWordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+
+	return strings.TrimSpace(decoded)
+}
+
+// dateZoneRx matches the time zone in parentheses after the date, like "(UTC+8)", "(GMT+0:00)", "(JST)" and "(MSK+3)"
+var dateZoneRx = regexp.MustCompile(`\s*\(([A-Z]{2,5})(?:([+-])(\d{1,2})(?::?(\d{2}))?)?\)$`)
+
+// dateZoneOffsets is the offset in minutes of the time zone names used in the parentheses
+var dateZoneOffsets = map[string]int{
+	"UTC": 0,
+	"GMT": 0,
+	"JST": 9 * 60,
+}
+
+// normalizeDateZone returns the date with the time zone in parentheses rewritten into a numeric offset,
+// as the layout can not parse the offset after the zone name, the offset of other zone names is taken as from UTC,
+// and other zone names without offset are kept without the parentheses
+func normalizeDateZone(datetime string) string {
+	m := dateZoneRx.FindStringSubmatchIndex(datetime)
+	if m == nil {
+		return datetime
+	}
+
+	name := datetime[m[2]:m[3]]
+	offset, ok := dateZoneOffsets[name]
+	if !ok && m[4] < 0 {
+		return datetime[:m[0]] + " " + name
+	}
+
+	if m[4] > 0 {
+		hours, _ := strconv.Atoi(datetime[m[6]:m[7]])
+		minutes := 0
+		if m[8] > 0 {
+			minutes, _ = strconv.Atoi(datetime[m[8]:m[9]])
+		}
+		if datetime[m[4]:m[5]] == "-" {
+			offset -= hours*60 + minutes
+		} else {
+			offset += hours*60 + minutes
+		}
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+
+	return fmt.Sprintf("%s %s%02d:%02d", datetime[:m[0]], sign, offset/60, offset%60)
+}
+
 // parseDateString attempts to parse a given date using a collection of common
 // format strings. Date formats containing time components are tried first
 // before attempts are made using date-only formats.
 func parseDateString(datetime string) (time.Time, error) {
 	datetime = strings.Trim(datetime, ".")
 	datetime = strings.ReplaceAll(datetime, ". ", "-")
+	datetime = normalizeDateZone(datetime)
 
 	formats := [...]string{
 		// Date & time formats
 		"2006-01-02 15:04:05",
 		"2006.01.02 15:04:05",
 		"02/01/2006 15:04:05",
+		"2/1/2006 15:04:05",
 		"02.01.2006 15:04:05",
 		"02.1.2006 15:04:05",
 		"2.1.2006 15:04:05",
@@ -140,9 +563,13 @@ func parseDateString(datetime string) (time.Time, error) {
 
 		// Date, time & time zone formats
 		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05-0700",
 		"2006-01-02 15:04:05-07",
+		"2006-01-02 15:04:05-07:00",
+		"2006-01-02 15:04:05 -07:00",
+		"2006-01-02 15:04:05 -0700",
+		"2006/01/02 15:04:05 -07:00",
 		"2006-01-02 15:04:05 MST",
-		"2006-01-02 15:04:05 (MST+3)",
 		time.UnixDate,
 		time.RubyDate,
 		time.RFC822,
@@ -155,16 +582,21 @@ func parseDateString(datetime string) (time.Time, error) {
 
 		// Date only formats
 		"2006-01-02",
+		"2006.01.02",
 		"02-Jan-2006",
 		"02.01.2006",
+		"2.1.2006",
 		"02-01-2006",
 		"January _2 2006",
 		"Mon Jan _2 2006",
 		"02/01/2006",
+		"2/1/2006",
 		"01/02/2006",
 		"2006/01/02",
 		"2006-Jan-02",
+		"2 January 2006",
 		"before Jan-2006",
+		"before 2 January 2006",
 	}
 
 	for _, format := range formats {
@@ -172,8 +604,42 @@ func parseDateString(datetime string) (time.Time, error) {
 		if err != nil {
 			continue
 		}
-		return result, nil
+		return fixTimeZone(result), nil
 	}
 
 	return time.Now(), fmt.Errorf("could not parse %s as a date", datetime)
 }
+
+// monthFirstExtensions is the extensions of registry using month first dates, like the Freenom "12/18/2001"
+var monthFirstExtensions = []string{"tk", "ml", "ga", "cf", "gq"}
+
+// parseDomainDate returns the parsed date of domain, the month first date is tried first for the extension using it
+func parseDomainDate(datetime, extension string) (time.Time, error) {
+	if assert.IsContains(monthFirstExtensions, extension) {
+		if result, err := time.Parse("01/02/2006", datetime); err == nil {
+			return result, nil
+		}
+	}
+
+	return parseDateString(datetime)
+}
+
+// fixTimeZone returns the time with only the offset of a named time zone, like "CST",
+// as RFC3339 keeps only the offset, so that the time unmarshals from JSON into the same,
+// which is UTC for the zero offset even if it matches the local time zone
+func fixTimeZone(t time.Time) time.Time {
+	if t.Location() == time.UTC {
+		return t
+	}
+
+	_, offset := t.Zone()
+	if offset == 0 {
+		return t.UTC()
+	}
+
+	if t.Location() == time.Local {
+		return t
+	}
+
+	return t.In(time.FixedZone("", offset))
+}
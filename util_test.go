@@ -21,6 +21,7 @@ package whoisparser
 
 import (
 	"testing"
+	"time"
 
 	"github.com/likexian/gokit/assert"
 )
@@ -34,12 +35,16 @@ func TestParseDateString(t *testing.T) {
 		{"09-Mar-2023"},
 		{"31-Jul-2022"},
 		{"2022-12-12T11:01:02Z"},
+		{"2020-08-02T00:00:00-0700"},
 		{"2022-12-03"},
 		{"2022. 12. 01."},
 		{"2022-12-12 11:40:12"},
 		{"2022.12.12 11:40:12"},
 		{"28/06/2022 23:59:59"},
+		{"7/3/2012 9:15:40"},
+		{"7/3/2027"},
 		{"24.10.2022"},
+		{"2024.01.01"},
 		{"2022-06-29 14:08:21+03"},
 		{"31.8.2025 00:00:00"},
 		{"01-10-2025"},
@@ -52,6 +57,8 @@ func TestParseDateString(t *testing.T) {
 		{"April 10 2023"},
 		{"2025-Dec-11"},
 		{"2025-Dec-11."},
+		{"31 May 1999"},
+		{"before 1 January 1996"},
 		{"2024-06-05 00:00:00 (UTC+8)"},
 		{"20221101 00:10:24"},
 	}
@@ -65,3 +72,85 @@ func TestParseDateString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDateStringTimeZone(t *testing.T) {
+	tests := []struct {
+		date string
+		utc  string
+	}{
+		{"2024-01-01T00:00:00Z", "2024-01-01T00:00:00Z"},
+		{"2024-01-01T00:00:00+00:00", "2024-01-01T00:00:00Z"},
+		{"2024-01-01T00:00:00+09:00", "2023-12-31T15:00:00Z"},
+		{"2024-01-01T00:00:00-0700", "2024-01-01T07:00:00Z"},
+		{"2024-01-01 00:00:00+09:00", "2023-12-31T15:00:00Z"},
+		{"2024-01-01 00:00:00 +0900", "2023-12-31T15:00:00Z"},
+		{"2024-01-01 00:00:00 (UTC+8)", "2023-12-31T16:00:00Z"},
+		{"2024-01-01 00:00:00 (GMT+0:00)", "2024-01-01T00:00:00Z"},
+		{"2024/01/01 09:00:00 (JST)", "2024-01-01T00:00:00Z"},
+		{"2020-01-02 03:04:05 (MSK+3)", "2020-01-02T00:04:05Z"},
+		{"2020-01-02 03:04:05 (EET+2)", "2020-01-02T01:04:05Z"},
+		{"2020-01-02 03:04:05 (EET)", "2020-01-02T03:04:05Z"},
+		{"2024-01-01 00:00:00", "2024-01-01T00:00:00Z"},
+		{"2024-01-01", "2024-01-01T00:00:00Z"},
+	}
+
+	for _, v := range tests {
+		result, err := parseDateString(v.date)
+		assert.Nil(t, err, v.date)
+		assert.Equal(t, result.UTC().Format(time.RFC3339), v.utc, v.date)
+	}
+
+	result, err := parseDateString("2024-01-01T00:00:00+00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, result.Location(), time.UTC)
+}
+
+func TestSplitContactLine(t *testing.T) {
+	tests := []struct {
+		line  string
+		name  string
+		phone string
+		email string
+	}{
+		{"Example Inc., admin@example.com", "Example Inc.", "", "admin@example.com"},
+		{"John Doe / +1.5551234567 / john@example.com", "John Doe", "+1.5551234567", "john@example.com"},
+		{"John Doe | john@example.com", "John Doe", "", "john@example.com"},
+		{"Example, Inc. <admin@example.com>", "Example, Inc.", "", "admin@example.com"},
+		{"John Doe (555) 123-4567", "John Doe", "(555) 123-4567", ""},
+		{"PRIVACYDOTLINK CUSTOMER 1078347", "PRIVACYDOTLINK CUSTOMER 1078347", "", ""},
+		{"BIN 080840007694", "BIN 080840007694", "", ""},
+		{"admin@example.com", "", "", "admin@example.com"},
+	}
+
+	for _, v := range tests {
+		name, phone, email := splitContactLine(v.line)
+		assert.Equal(t, name, v.name, v.line)
+		assert.Equal(t, phone, v.phone, v.line)
+		assert.Equal(t, email, v.email, v.line)
+	}
+}
+
+func TestSplitAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		street  string
+		city    string
+		country string
+	}{
+		{"Knez Mihailova 12, Beograd, Serbia", "Knez Mihailova 12", "Beograd", "Serbia"},
+		{"Main Street 1, Suite 2, Dublin, Ireland", "Main Street 1, Suite 2", "Dublin", "Ireland"},
+		{"Bulevar oslobodjenja 5, 21000 Novi Sad, Serbia", "Bulevar oslobodjenja 5, 21000 Novi Sad, Serbia", "", ""},
+		{"1600 Amphitheatre Parkway, Mountain View, CA 94043", "1600 Amphitheatre Parkway, Mountain View, CA 94043",
+			"", ""},
+		{"Partizanska 12, Skopje", "Partizanska 12, Skopje", "", ""},
+		{"Knez Mihailova 12, , Serbia", "Knez Mihailova 12, , Serbia", "", ""},
+		{"", "", "", ""},
+	}
+
+	for _, v := range tests {
+		street, city, country := splitAddress(v.address)
+		assert.Equal(t, street, v.street, v.address)
+		assert.Equal(t, city, v.city, v.address)
+		assert.Equal(t, country, v.country, v.address)
+	}
+}